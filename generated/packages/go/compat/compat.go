@@ -0,0 +1,888 @@
+// Package compat decides whether a JSON Schema accepts every value another one
+// accepts, which is what makes changing one schema into the other safe for the
+// values it describes.
+//
+// The check is conservative: Compatible and Incompatible are only answered when the
+// keywords of both schemas settle the question, and Unknown is answered otherwise,
+// naming the keyword that could not be decided. Schemas are taken to be satisfiable,
+// that is their keywords are assumed to allow some value of every type they admit.
+// Check does not follow references, so schemas should be dereferenced first, while
+// CheckRefs follows them, which lets it compare recursive schemas too.
+package compat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/jsonschema"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Result answers whether every value valid under a schema is valid under another.
+type Result int
+
+const (
+	Unknown Result = iota
+	Compatible
+	Incompatible
+)
+
+func (r Result) String() string {
+	switch r {
+	case Unknown:
+		return "unknown"
+	case Compatible:
+		return "compatible"
+	case Incompatible:
+		return "incompatible"
+	}
+	return "Result(" + strconv.Itoa(int(r)) + ")"
+}
+
+// Verdict is the outcome of a check, along with the keyword it hinges on for
+// Incompatible and Unknown results.
+type Verdict struct {
+	Result Result
+	// Keyword is the keyword responsible for the result, such as "minimum".
+	Keyword string
+	// Path is the JSON pointer of Keyword within the second schema, or, for keywords
+	// of the first schema such as "not", of the place in the second schema it was
+	// compared with.
+	Path   string
+	Reason string
+}
+
+func (v Verdict) String() string {
+	if v.Result == Compatible {
+		return v.Result.String()
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", v.Result, pointer(v.Path), v.Reason, v.Keyword)
+}
+
+// Check reports whether every value valid under a is also valid under b. A nil
+// schema accepts any value.
+func Check(a, b *v1_4.JSONSchema) Verdict {
+	return (&checker{}).check(a, b, "")
+}
+
+// Resolver returns the schema a reference refers to.
+type Resolver func(ref string) (*v1_4.JSONSchema, error)
+
+// CheckRefs is Check following the references of a with resolveA and those of b
+// with resolveB, such as those left in recursive schemas by dereferencing. A pair of
+// references met again while they are being compared is taken to be compatible, as
+// whatever settles the check lies elsewhere. Keywords reached through a reference of b
+// are located as if the schema it refers to replaced it.
+func CheckRefs(a, b *v1_4.JSONSchema, resolveA, resolveB Resolver) Verdict {
+	c := &checker{resolveA: resolveA, resolveB: resolveB, comparing: map[[2]string]bool{}}
+	return c.check(a, b, "")
+}
+
+// checker holds the state of a check: how to follow the references of each schema,
+// and the pairs of references being compared.
+type checker struct {
+	resolveA, resolveB Resolver
+	comparing          map[[2]string]bool
+}
+
+var compatible = Verdict{Result: Compatible}
+
+func unknown(path, keyword, format string, args ...interface{}) Verdict {
+	return Verdict{Result: Unknown, Keyword: keyword, Path: path + "/" + keyword, Reason: fmt.Sprintf(format, args...)}
+}
+
+func incompatible(path, keyword, format string, args ...interface{}) Verdict {
+	return Verdict{Result: Incompatible, Keyword: keyword, Path: path + "/" + keyword, Reason: fmt.Sprintf(format, args...)}
+}
+
+// verdicts combines the verdicts of the keywords of a schema: the first Incompatible
+// one wins, then the first Unknown one.
+type verdicts struct {
+	unknown *Verdict
+}
+
+// add records v, returning true when it settles the check as Incompatible.
+func (vs *verdicts) add(v Verdict) bool {
+	switch v.Result {
+	case Incompatible:
+		return true
+	case Unknown:
+		if vs.unknown == nil {
+			vs.unknown = &v
+		}
+	}
+	return false
+}
+
+func (vs *verdicts) result() Verdict {
+	if vs.unknown != nil {
+		return *vs.unknown
+	}
+	return compatible
+}
+
+func (c *checker) check(a, b *v1_4.JSONSchema, path string) Verdict {
+	if isTrue(b) || isFalse(a) {
+		return compatible
+	}
+	if isFalse(b) {
+		return Verdict{Result: Incompatible, Keyword: "false", Path: path, Reason: "the second schema accepts no value"}
+	}
+	x, y := object(a), object(b)
+	if c.same(a, b) {
+		return compatible
+	}
+	if x.Ref != nil || y.Ref != nil {
+		return c.checkRefs(a, b, path)
+	}
+	if x.Enum != nil || len(x.Const) > 0 {
+		return checkValues(a, b, path)
+	}
+	if hasCombinators(x) {
+		return c.checkCombinatorsOf(x, b, path)
+	}
+	var vs verdicts
+	for _, v := range []Verdict{
+		c.checkCombinators(a, y, path),
+		checkType(x, y, path),
+	} {
+		if vs.add(v) {
+			return v
+		}
+	}
+	types := allowedTypes(x)
+	if types["number"] || types["integer"] {
+		if v := checkNumber(x, y, !types["number"], path); vs.add(v) {
+			return v
+		}
+	}
+	if types["string"] {
+		if v := checkString(x, y, path); vs.add(v) {
+			return v
+		}
+	}
+	if types["array"] {
+		if v := c.checkArray(x, y, path); vs.add(v) {
+			return v
+		}
+	}
+	if types["object"] {
+		if v := c.checkObject(x, y, path); vs.add(v) {
+			return v
+		}
+	}
+	return vs.result()
+}
+
+// same reports whether a and b have the same keywords. This settles that they accept
+// the same values unless references are followed, as the references they hold may
+// then refer to different schemas in each document.
+func (c *checker) same(a, b interface{}) bool {
+	v := value(a)
+	if !reflect.DeepEqual(v, value(b)) {
+		return false
+	}
+	return c.resolveA == nil && c.resolveB == nil || !hasRef(v)
+}
+
+// checkRefs checks a against b, following the reference of either.
+func (c *checker) checkRefs(a, b *v1_4.JSONSchema, path string) Verdict {
+	pair := [2]string{ref(a), ref(b)}
+	if c.comparing[pair] {
+		return compatible
+	}
+	targetA, err := follow(c.resolveA, a)
+	if err != nil {
+		return unknown(path, "$ref", "%v", err)
+	}
+	targetB, err := follow(c.resolveB, b)
+	if err != nil {
+		return unknown(path, "$ref", "%v", err)
+	}
+	c.comparing[pair] = true
+	defer delete(c.comparing, pair)
+	return c.check(targetA, targetB, path)
+}
+
+// follow returns the schema s refers to, or s itself when it is no reference.
+func follow(resolve Resolver, s *v1_4.JSONSchema) (*v1_4.JSONSchema, error) {
+	r := ref(s)
+	switch {
+	case r == "":
+		return s, nil
+	case resolve == nil:
+		return nil, errors.New("references are not followed")
+	}
+	target, err := resolve(r)
+	if err != nil {
+		return nil, fmt.Errorf("the reference %q cannot be resolved: %v", r, err)
+	}
+	return target, nil
+}
+
+func ref(s *v1_4.JSONSchema) string {
+	if o := object(s); o.Ref != nil {
+		return string(*o.Ref)
+	}
+	return ""
+}
+
+// hasRef reports whether the decoded JSON value v holds a "$ref" member.
+func hasRef(v interface{}) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if _, ok := v["$ref"]; ok {
+			return true
+		}
+		for _, member := range v {
+			if hasRef(member) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, element := range v {
+			if hasRef(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkValues checks a schema listing its values with enum or const by validating
+// each value it accepts against b.
+func checkValues(a, b *v1_4.JSONSchema, path string) Verdict {
+	x := object(a)
+	var values []interface{}
+	if x.Enum != nil {
+		for _, v := range *x.Enum {
+			values = append(values, v)
+		}
+	}
+	if len(x.Const) > 0 {
+		value, err := jsonschema.Decode(x.Const)
+		if err != nil {
+			return unknown(path, "const", "the const value cannot be decoded")
+		}
+		values = append(values, value)
+	}
+	validatorA, errA := jsonschema.New(a)
+	validatorB, errB := jsonschema.New(b)
+	if errA != nil || errB != nil {
+		return unknown(path, "enum", "the schemas cannot be compiled")
+	}
+	for _, v := range values {
+		if len(validatorA.Validate(v)) > 0 {
+			continue
+		}
+		if errs := validatorB.Validate(v); len(errs) > 0 {
+			e := errs[0]
+			return Verdict{Result: Incompatible, Keyword: e.Keyword, Path: path + e.SchemaPath, Reason: fmt.Sprintf("the value %s is not accepted: %s", describe(v), e.Message)}
+		}
+	}
+	return compatible
+}
+
+func hasCombinators(o *v1_4.JSONSchemaObject) bool {
+	return o.AllOf != nil || o.AnyOf != nil || o.OneOf != nil || o.Not != nil || o.If != nil
+}
+
+// checkCombinatorsOf checks a schema with combinators against b. Combinators only
+// narrow a, so a is compatible when a without them is, when one of its allOf
+// schemas is, or when each of its anyOf or oneOf schemas is.
+func (c *checker) checkCombinatorsOf(x *v1_4.JSONSchemaObject, b *v1_4.JSONSchema, path string) Verdict {
+	core := *x
+	core.AllOf, core.AnyOf, core.OneOf, core.Not, core.If, core.Then, core.Else = nil, nil, nil, nil, nil, nil, nil
+	coreSchema := &v1_4.JSONSchema{JSONSchemaObject: &core}
+	if c.check(coreSchema, b, path).Result == Compatible {
+		return compatible
+	}
+	if x.AllOf != nil {
+		for i := range *x.AllOf {
+			if c.check(&(*x.AllOf)[i], b, path).Result == Compatible {
+				return compatible
+			}
+		}
+	}
+	for _, branches := range []*v1_4.SchemaArray{x.AnyOf, x.OneOf} {
+		if branches == nil {
+			continue
+		}
+		all := true
+		for i := range *branches {
+			v := c.check(&(*branches)[i], b, path)
+			// A value of a failing anyOf branch is valid under a when nothing else
+			// constrains a, while oneOf would still refuse values of several branches.
+			if v.Result == Incompatible && branches == x.AnyOf && isTrue(coreSchema) && x.AllOf == nil && x.OneOf == nil && x.Not == nil && x.If == nil {
+				return v
+			}
+			all = all && v.Result == Compatible
+		}
+		if all {
+			return compatible
+		}
+	}
+	for _, keyword := range []struct {
+		name    string
+		present bool
+	}{
+		{"allOf", x.AllOf != nil},
+		{"anyOf", x.AnyOf != nil},
+		{"oneOf", x.OneOf != nil},
+		{"not", x.Not != nil},
+		{"if", x.If != nil},
+	} {
+		if keyword.present {
+			return unknown(path, keyword.name, "the first schema's %s cannot be compared", keyword.name)
+		}
+	}
+	return compatible
+}
+
+// checkCombinators checks a against the combinators of b.
+func (c *checker) checkCombinators(a *v1_4.JSONSchema, y *v1_4.JSONSchemaObject, path string) Verdict {
+	var vs verdicts
+	if y.AllOf != nil {
+		for i := range *y.AllOf {
+			if v := c.check(a, &(*y.AllOf)[i], path+"/allOf/"+strconv.Itoa(i)); vs.add(v) {
+				return v
+			}
+		}
+	}
+	if y.AnyOf != nil && !c.anyCompatible(a, *y.AnyOf, path+"/anyOf") {
+		vs.add(unknown(path, "anyOf", "no single anyOf schema accepts every value"))
+	}
+	if y.OneOf != nil {
+		// a fits oneOf when exactly one schema accepts its values and the others
+		// accept none of its types.
+		fits := 0
+		types := allowedTypes(object(a))
+		for i := range *y.OneOf {
+			branch := &(*y.OneOf)[i]
+			switch {
+			case c.check(a, branch, path).Result == Compatible:
+				fits++
+			case !disjoint(types, branch):
+				fits = 2
+			}
+		}
+		if fits != 1 {
+			vs.add(unknown(path, "oneOf", "values may be accepted by none or several oneOf schemas"))
+		}
+	}
+	if y.Not != nil && !disjoint(allowedTypes(object(a)), y.Not) {
+		vs.add(unknown(path, "not", "values may be accepted by the not schema"))
+	}
+	if y.If != nil {
+		then, otherwise := c.check(a, y.Then, path+"/then"), c.check(a, y.Else, path+"/else")
+		if then.Result != Compatible || otherwise.Result != Compatible {
+			vs.add(unknown(path, "if", "values may fail the then or else schema"))
+		}
+	}
+	return vs.result()
+}
+
+func (c *checker) anyCompatible(a *v1_4.JSONSchema, branches v1_4.SchemaArray, path string) bool {
+	for i := range branches {
+		if c.check(a, &branches[i], path+"/"+strconv.Itoa(i)).Result == Compatible {
+			return true
+		}
+	}
+	return false
+}
+
+// disjoint reports whether s refuses every value of the given types.
+func disjoint(types map[string]bool, s *v1_4.JSONSchema) bool {
+	if isFalse(s) {
+		return true
+	}
+	o := object(s)
+	if o.Type == nil {
+		return false
+	}
+	other := allowedTypes(o)
+	for t := range types {
+		if other[t] {
+			return false
+		}
+	}
+	return true
+}
+
+func checkType(x, y *v1_4.JSONSchemaObject, path string) Verdict {
+	if y.Type == nil {
+		return compatible
+	}
+	allowed, types := allowedTypes(y), allowedTypes(x)
+	for _, t := range sorted.Keys(types) {
+		// Integers are reported as the numbers they are part of.
+		if !allowed[t] && !(t == "integer" && types["number"]) {
+			return incompatible(path, "type", "values of type %s are accepted", t)
+		}
+	}
+	return compatible
+}
+
+// bound is a limit on numbers.
+type bound struct {
+	value     *big.Rat
+	exclusive bool
+}
+
+// lower returns the lower bound of o, or nil when it has none. For integers the bound
+// is the smallest integer allowed.
+func lower(o *v1_4.JSONSchemaObject, integer bool) *bound {
+	var b *bound
+	if o.Minimum != nil {
+		b = &bound{value: rat(float64(*o.Minimum))}
+	}
+	if o.ExclusiveMinimum != nil {
+		v := rat(float64(*o.ExclusiveMinimum))
+		if b == nil || v.Cmp(b.value) >= 0 {
+			b = &bound{value: v, exclusive: true}
+		}
+	}
+	if b != nil && integer {
+		n := new(big.Int).Div(b.value.Num(), b.value.Denom())
+		if b.exclusive || new(big.Rat).SetInt(n).Cmp(b.value) < 0 {
+			n.Add(n, big.NewInt(1))
+		}
+		b = &bound{value: new(big.Rat).SetInt(n)}
+	}
+	return b
+}
+
+// upper returns the upper bound of o, or nil when it has none. For integers the bound
+// is the largest integer allowed.
+func upper(o *v1_4.JSONSchemaObject, integer bool) *bound {
+	var b *bound
+	if o.Maximum != nil {
+		b = &bound{value: rat(float64(*o.Maximum))}
+	}
+	if o.ExclusiveMaximum != nil {
+		v := rat(float64(*o.ExclusiveMaximum))
+		if b == nil || v.Cmp(b.value) <= 0 {
+			b = &bound{value: v, exclusive: true}
+		}
+	}
+	if b != nil && integer {
+		// Div rounds towards negative infinity for positive denominators.
+		n := new(big.Int).Div(b.value.Num(), b.value.Denom())
+		if b.exclusive && new(big.Rat).SetInt(n).Cmp(b.value) == 0 {
+			n.Sub(n, big.NewInt(1))
+		}
+		b = &bound{value: new(big.Rat).SetInt(n)}
+	}
+	return b
+}
+
+func checkNumber(x, y *v1_4.JSONSchemaObject, integer bool, path string) Verdict {
+	if limit := lower(y, false); limit != nil {
+		keyword := "minimum"
+		if limit.exclusive {
+			keyword = "exclusiveMinimum"
+		}
+		have := lower(x, integer)
+		if have == nil {
+			return incompatible(path, keyword, "numbers are not bounded below")
+		}
+		if c := have.value.Cmp(limit.value); c < 0 || c == 0 && limit.exclusive && !have.exclusive {
+			return incompatible(path, keyword, "numbers down to %s are accepted", have.value.RatString())
+		}
+	}
+	if limit := upper(y, false); limit != nil {
+		keyword := "maximum"
+		if limit.exclusive {
+			keyword = "exclusiveMaximum"
+		}
+		have := upper(x, integer)
+		if have == nil {
+			return incompatible(path, keyword, "numbers are not bounded above")
+		}
+		if c := have.value.Cmp(limit.value); c > 0 || c == 0 && limit.exclusive && !have.exclusive {
+			return incompatible(path, keyword, "numbers up to %s are accepted", have.value.RatString())
+		}
+	}
+	if y.MultipleOf != nil {
+		divisor := rat(float64(*y.MultipleOf))
+		step := big.NewRat(1, 1)
+		switch {
+		case x.MultipleOf != nil:
+			step = rat(float64(*x.MultipleOf))
+		case !integer:
+			return incompatible(path, "multipleOf", "numbers are not restricted to multiples of %s", divisor.RatString())
+		}
+		if !new(big.Rat).Quo(step, divisor).IsInt() {
+			return incompatible(path, "multipleOf", "multiples of %s are accepted", step.RatString())
+		}
+	}
+	return compatible
+}
+
+func checkString(x, y *v1_4.JSONSchemaObject, path string) Verdict {
+	if v := checkMin(intOf(x.MinLength), intOf(y.MinLength), path, "minLength", "strings"); v.Result != Compatible {
+		return v
+	}
+	if v := checkMax(intOf(x.MaxLength), intOf(y.MaxLength), path, "maxLength", "strings"); v.Result != Compatible {
+		return v
+	}
+	if y.Pattern != nil && (x.Pattern == nil || *x.Pattern != *y.Pattern) {
+		return unknown(path, "pattern", "patterns cannot be compared")
+	}
+	if y.Format != nil && (x.Format == nil || *x.Format != *y.Format) {
+		return unknown(path, "format", "formats cannot be compared")
+	}
+	return compatible
+}
+
+func (c *checker) checkArray(x, y *v1_4.JSONSchemaObject, path string) Verdict {
+	if v := checkMin(intOf(x.MinItems), intOf(y.MinItems), path, "minItems", "arrays"); v.Result != Compatible {
+		return v
+	}
+	if v := checkMax(intOf(x.MaxItems), intOf(y.MaxItems), path, "maxItems", "arrays"); v.Result != Compatible {
+		return v
+	}
+	if y.UniqueItems != nil && bool(*y.UniqueItems) && (x.UniqueItems == nil || !bool(*x.UniqueItems)) {
+		return incompatible(path, "uniqueItems", "arrays with duplicate items are accepted")
+	}
+	if y.Contains != nil && (x.Contains == nil || !c.same(x.Contains, y.Contains)) {
+		return unknown(path, "contains", "contains schemas cannot be compared")
+	}
+	// Compare the schema of each position up to the longest tuple, then the schemas
+	// of the positions past it.
+	length := len(tuple(x)) + 1
+	if n := len(tuple(y)) + 1; n > length {
+		length = n
+	}
+	max := intOf(x.MaxItems)
+	var vs verdicts
+	for i := 0; i < length; i++ {
+		if max != nil && int64(i) >= *max {
+			break
+		}
+		itemA, _ := item(x, i)
+		itemB, where := item(y, i)
+		if where == "" {
+			continue
+		}
+		if v := c.check(itemA, itemB, path+where); vs.add(v) {
+			return v
+		}
+	}
+	return vs.result()
+}
+
+func tuple(o *v1_4.JSONSchemaObject) v1_4.SchemaArray {
+	if o.Items == nil || o.Items.SchemaArray == nil {
+		return nil
+	}
+	return *o.Items.SchemaArray
+}
+
+// item returns the schema of position i of arrays, along with the path of the
+// keyword it comes from, which is empty when any value is accepted.
+func item(o *v1_4.JSONSchemaObject, i int) (*v1_4.JSONSchema, string) {
+	switch {
+	case o.Items == nil:
+		return nil, ""
+	case o.Items.JSONSchema != nil:
+		return o.Items.JSONSchema, "/items"
+	case i < len(tuple(o)):
+		return &tuple(o)[i], "/items/" + strconv.Itoa(i)
+	case o.AdditionalItems != nil:
+		return o.AdditionalItems, "/additionalItems"
+	}
+	return nil, ""
+}
+
+func (c *checker) checkObject(x, y *v1_4.JSONSchemaObject, path string) Verdict {
+	if y.Required != nil {
+		have := map[string]bool{}
+		if x.Required != nil {
+			for _, name := range *x.Required {
+				have[string(name)] = true
+			}
+		}
+		for _, name := range *y.Required {
+			if !have[string(name)] {
+				return incompatible(path, "required", "objects without %q are accepted", string(name))
+			}
+		}
+	}
+	if v := checkMin(intOf(x.MinProperties), intOf(y.MinProperties), path, "minProperties", "objects"); v.Result != Compatible {
+		return v
+	}
+	if v := checkMax(intOf(x.MaxProperties), intOf(y.MaxProperties), path, "maxProperties", "objects"); v.Result != Compatible {
+		return v
+	}
+	var vs verdicts
+	if y.Dependencies != nil {
+		for _, name := range sorted.Keys(*y.Dependencies) {
+			if !c.dependencyHolds(x, name, (*y.Dependencies)[name]) {
+				vs.add(Verdict{Result: Unknown, Keyword: "dependencies", Path: path + "/dependencies/" + source.EscapeToken(name), Reason: fmt.Sprintf("the dependency on %q cannot be compared", name)})
+			}
+		}
+	}
+	if y.PropertyNames != nil {
+		names := x.PropertyNames
+		if names == nil {
+			names = &v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{Type: &v1_4.Type{SimpleTypes: simpleType("string")}}}
+		}
+		if v := c.check(names, y.PropertyNames, path+"/propertyNames"); vs.add(v) {
+			return v
+		}
+	}
+	patternsA, patternsB := patterns(x), patterns(y)
+	if !c.same(x.PatternProperties, y.PatternProperties) {
+		return unknown(path, "patternProperties", "pattern properties cannot be compared")
+	}
+	if patternsA == nil || patternsB == nil {
+		return unknown(path, "patternProperties", "the patterns cannot be compiled")
+	}
+	names := map[string]bool{}
+	for _, o := range []*v1_4.JSONSchemaObject{x, y} {
+		if o.Properties != nil {
+			for name := range *o.Properties {
+				names[name] = true
+			}
+		}
+	}
+	for _, name := range sorted.Keys(names) {
+		propertyA, _ := property(x, name, patternsA)
+		if isFalse(propertyA) {
+			continue
+		}
+		propertyB, where := property(y, name, patternsB)
+		if where == "" {
+			continue
+		}
+		if v := c.check(propertyA, propertyB, path+where); vs.add(v) {
+			return v
+		}
+	}
+	if y.AdditionalProperties != nil {
+		additional := x.AdditionalProperties
+		if v := c.check(additional, y.AdditionalProperties, path+"/additionalProperties"); vs.add(v) {
+			return v
+		}
+	}
+	return vs.result()
+}
+
+// property returns the schema of the property name, along with the path of the
+// keyword it comes from, which is empty when any value is accepted. Pattern
+// properties, which both schemas share, are left out.
+func property(o *v1_4.JSONSchemaObject, name string, patterns []*regexp.Regexp) (*v1_4.JSONSchema, string) {
+	if o.Properties != nil {
+		if s, ok := (*o.Properties)[name]; ok {
+			return &s, "/properties/" + source.EscapeToken(name)
+		}
+	}
+	for _, p := range patterns {
+		if p.MatchString(name) {
+			return nil, ""
+		}
+	}
+	if o.AdditionalProperties != nil {
+		return o.AdditionalProperties, "/additionalProperties"
+	}
+	return nil, ""
+}
+
+// patterns compiles the patternProperties of o, returning nil when one is invalid.
+func patterns(o *v1_4.JSONSchemaObject) []*regexp.Regexp {
+	compiled := []*regexp.Regexp{}
+	if o.PatternProperties == nil {
+		return compiled
+	}
+	for _, p := range sorted.Keys(*o.PatternProperties) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// dependencyHolds reports whether objects of x always satisfy the dependency of b on
+// the property name.
+func (c *checker) dependencyHolds(x *v1_4.JSONSchemaObject, name string, dependency v1_4.DependenciesSet) bool {
+	if x.Dependencies != nil {
+		if own, ok := (*x.Dependencies)[name]; ok && c.same(own, dependency) {
+			return true
+		}
+	}
+	if dependency.StringArray == nil || x.Required == nil {
+		return false
+	}
+	have := map[string]bool{}
+	for _, required := range *x.Required {
+		have[string(required)] = true
+	}
+	for _, required := range *dependency.StringArray {
+		if !have[string(required)] {
+			return false
+		}
+	}
+	return true
+}
+
+func checkMin(have, limit *int64, path, keyword, kind string) Verdict {
+	if limit != nil && *limit > 0 && (have == nil || *have < *limit) {
+		n := int64(0)
+		if have != nil {
+			n = *have
+		}
+		return incompatible(path, keyword, "%s of size %d are accepted", kind, n)
+	}
+	return compatible
+}
+
+func checkMax(have, limit *int64, path, keyword, kind string) Verdict {
+	if limit == nil {
+		return compatible
+	}
+	if have == nil {
+		return incompatible(path, keyword, "%s of any size are accepted", kind)
+	}
+	if *have > *limit {
+		return incompatible(path, keyword, "%s of size %d are accepted", kind, *have)
+	}
+	return compatible
+}
+
+func intOf[T ~int64](p *T) *int64 {
+	if p == nil {
+		return nil
+	}
+	n := int64(*p)
+	return &n
+}
+
+// allTypes are the types of JSON values, with integers among the numbers.
+var allTypes = []string{"array", "boolean", "integer", "null", "number", "object", "string"}
+
+// allowedTypes returns the types o accepts values of. A number type implies the
+// integer one.
+func allowedTypes(o *v1_4.JSONSchemaObject) map[string]bool {
+	types := map[string]bool{}
+	if o.Type == nil {
+		for _, t := range allTypes {
+			types[t] = true
+		}
+		return types
+	}
+	if o.Type.SimpleTypes != nil {
+		types[string(*o.Type.SimpleTypes)] = true
+	}
+	if o.Type.ArrayOfSimpleTypes != nil {
+		for _, t := range *o.Type.ArrayOfSimpleTypes {
+			types[string(t)] = true
+		}
+	}
+	if types["number"] {
+		types["integer"] = true
+	}
+	return types
+}
+
+func simpleType(t string) *v1_4.SimpleTypes {
+	s := v1_4.SimpleTypes(t)
+	return &s
+}
+
+// annotations are keywords that do not change the values a schema accepts.
+var annotations = map[string]bool{
+	"$id":              true,
+	"$schema":          true,
+	"$comment":         true,
+	"title":            true,
+	"description":      true,
+	"default":          true,
+	"readOnly":         true,
+	"examples":         true,
+	"definitions":      true,
+	"contentMediaType": true,
+	"contentEncoding":  true,
+}
+
+// isTrue reports whether s accepts any value.
+func isTrue(s *v1_4.JSONSchema) bool {
+	if s == nil || s.JSONSchemaBoolean != nil && bool(*s.JSONSchemaBoolean) {
+		return true
+	}
+	if s.JSONSchemaObject == nil {
+		return false
+	}
+	object, ok := value(s).(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for keyword := range object {
+		if !annotations[keyword] {
+			return false
+		}
+	}
+	return true
+}
+
+func isFalse(s *v1_4.JSONSchema) bool {
+	return s != nil && s.JSONSchemaBoolean != nil && !bool(*s.JSONSchemaBoolean)
+}
+
+// object returns the keywords of s, with true and nil schemas having none.
+func object(s *v1_4.JSONSchema) *v1_4.JSONSchemaObject {
+	if s == nil || s.JSONSchemaObject == nil {
+		return &v1_4.JSONSchemaObject{}
+	}
+	return s.JSONSchemaObject
+}
+
+// value returns v as decoded JSON, without annotations when it is a schema.
+func value(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	if object, ok := decoded.(map[string]interface{}); ok {
+		for keyword := range annotations {
+			delete(object, keyword)
+		}
+	}
+	return decoded
+}
+
+func rat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(f)
+	}
+	return r
+}
+
+func describe(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func pointer(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return p
+}
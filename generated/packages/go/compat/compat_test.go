@@ -0,0 +1,151 @@
+package compat
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+func schema(t *testing.T, data string) *v1_4.JSONSchema {
+	t.Helper()
+	var s v1_4.JSONSchema
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatal(err)
+	}
+	return &s
+}
+
+// resolver resolves references to "#/definitions/<name>" among definitions.
+func resolver(t *testing.T, definitions map[string]string) Resolver {
+	return func(ref string) (*v1_4.JSONSchema, error) {
+		data, ok := definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		if !ok {
+			return nil, errors.New("no such definition")
+		}
+		return schema(t, data), nil
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, c := range []struct {
+		a, b          string
+		want          Result
+		keyword, path string
+	}{
+		{`{"type":"string"}`, `{"type":["string","number"]}`, Compatible, "", ""},
+		{`{"type":["string","number"]}`, `{"type":"string"}`, Incompatible, "type", "/type"},
+		{`{"type":"integer"}`, `{"type":"number"}`, Compatible, "", ""},
+		{`{"type":"number"}`, `{"type":"integer"}`, Incompatible, "type", "/type"},
+		{`{"type":"number","minimum":5}`, `{"type":"number","minimum":3}`, Compatible, "", ""},
+		{`{"type":"number","minimum":3}`, `{"type":"number","minimum":5}`, Incompatible, "minimum", "/minimum"},
+		{`{"type":"integer","exclusiveMinimum":4}`, `{"minimum":5}`, Compatible, "", ""},
+		{`{"type":"integer","minimum":4.5}`, `{"minimum":5}`, Compatible, "", ""},
+		{`{"type":"number","minimum":4.5}`, `{"minimum":5}`, Incompatible, "minimum", "/minimum"},
+		{`{"type":"integer"}`, `{"multipleOf":0.5}`, Compatible, "", ""},
+		{`{"multipleOf":4}`, `{"multipleOf":2}`, Compatible, "", ""},
+		{`{"type":"object","required":["a","b"]}`, `{"type":"object","required":["a"]}`, Compatible, "", ""},
+		{`{"type":"object","required":["a"]}`, `{"type":"object","required":["a","b"]}`, Incompatible, "required", "/required"},
+		{`{"enum":[1,2]}`, `{"enum":[1,2,3]}`, Compatible, "", ""},
+		{`{"enum":[1,2,3]}`, `{"enum":[1,2]}`, Incompatible, "enum", "/enum"},
+		{`{"enum":[1,2,3],"maximum":2}`, `{"enum":[1,2]}`, Compatible, "", ""},
+		{`{"type":"string","pattern":"^a"}`, `{"type":"string","pattern":"^b"}`, Unknown, "pattern", "/pattern"},
+		{`{"type":"string","minLength":2}`, `{"type":"string"}`, Compatible, "", ""},
+		{`{"type":"string"}`, `{"type":"string","minLength":2}`, Incompatible, "minLength", "/minLength"},
+		{`{"properties":{"a":{"type":"integer"}}}`, `{"properties":{"a":{"type":"number"}}}`, Compatible, "", ""},
+		{`{"properties":{"a":{"type":"number"}}}`, `{"properties":{"a":{"type":"integer"}}}`, Incompatible, "type", "/properties/a/type"},
+		{`{"properties":{"a":{}},"additionalProperties":false}`, `{"additionalProperties":false}`, Incompatible, "false", "/additionalProperties"},
+		{`{"additionalProperties":false}`, `{"properties":{"a":{"type":"string"}},"additionalProperties":false}`, Compatible, "", ""},
+		{`{"type":"array","items":{"type":"integer"}}`, `{"type":"array","items":{"type":"number"}}`, Compatible, "", ""},
+		{`{"type":"array","items":[{"type":"integer"}],"additionalItems":false}`, `{"type":"array","items":{"type":"number"}}`, Compatible, "", ""},
+		{`{"type":"array","items":[{"type":"integer"}]}`, `{"type":"array","items":{"type":"number"}}`, Incompatible, "type", "/items/type"},
+		{`{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `{"type":["string","number"]}`, Compatible, "", ""},
+		{`{"anyOf":[{"type":"string"},{"type":"null"}]}`, `{"type":"string"}`, Incompatible, "type", "/type"},
+		{`{"type":"string"}`, `{"anyOf":[{"type":"string"},{"type":"null"}]}`, Compatible, "", ""},
+		{`{"type":"string"}`, `{"oneOf":[{"type":"string"},{"type":"null"}]}`, Compatible, "", ""},
+		{`{"type":"string"}`, `{"not":{"type":"null"}}`, Compatible, "", ""},
+		{`{"type":"string","not":{"const":"x"}}`, `{"type":"string"}`, Compatible, "", ""},
+		{`{"type":"string","not":{"const":"x"}}`, `{"type":"string","minLength":1}`, Unknown, "not", "/not"},
+		{`{"$ref":"#/definitions/a"}`, `{"type":"string"}`, Unknown, "$ref", "/$ref"},
+		{`true`, `{}`, Compatible, "", ""},
+		{`true`, `false`, Incompatible, "false", ""},
+		{`{"type":"string","title":"x"}`, `{"type":"string","description":"y"}`, Compatible, "", ""},
+		{`{"allOf":[{"type":"string"},{"minLength":3}]}`, `{"type":"string"}`, Compatible, "", ""},
+		{`{"type":"string"}`, `{"allOf":[{"type":"string"},{"minLength":3}]}`, Incompatible, "minLength", "/allOf/1/minLength"},
+		{`{"type":"object","dependencies":{"a":["b"]}}`, `{"type":"object","dependencies":{"a":["b"]}}`, Compatible, "", ""},
+		{`{"type":"object"}`, `{"type":"object","dependencies":{"a":["b"]}}`, Unknown, "dependencies", "/dependencies/a"},
+		{`{"const": null}`, `{"type": "null"}`, Compatible, "", ""},
+		{`{"const": 1}`, `{"type": "string"}`, Incompatible, "type", "/type"},
+		{`{"type": "array", "uniqueItems": true}`, `{"type": "array"}`, Compatible, "", ""},
+		{`{"type": "array"}`, `{"type": "array", "uniqueItems": true}`, Incompatible, "uniqueItems", "/uniqueItems"},
+		{`{"type": "array", "maxItems": 3}`, `{"type": "array", "maxItems": 2}`, Incompatible, "maxItems", "/maxItems"},
+		{`{"type": "integer", "maximum": 9}`, `{"exclusiveMaximum": 10}`, Compatible, "", ""},
+		{`{"type": "number", "maximum": 10}`, `{"exclusiveMaximum": 10}`, Incompatible, "exclusiveMaximum", "/exclusiveMaximum"},
+		{`{"type": "object"}`, `{"type": "object", "propertyNames": {"maxLength": 3}}`, Incompatible, "maxLength", "/propertyNames/maxLength"},
+		{`{"type": "object", "maxProperties": 2}`, `{"type": "object", "maxProperties": 3}`, Compatible, "", ""},
+	} {
+		v := Check(schema(t, c.a), schema(t, c.b))
+		if v.Result != c.want || v.Keyword != c.keyword || v.Path != c.path {
+			t.Errorf("%s against %s: got %v (%s at %q), want %v (%s at %q)", c.a, c.b, v.Result, v.Keyword, v.Path, c.want, c.keyword, c.path)
+		}
+	}
+}
+
+func TestVerdictString(t *testing.T) {
+	if got := Check(schema(t, `true`), schema(t, `false`)).String(); got != "incompatible: /: the second schema accepts no value (false)" {
+		t.Errorf("got %q", got)
+	}
+	if got := Check(schema(t, `{}`), schema(t, `{}`)).String(); got != "compatible" {
+		t.Errorf("got %q", got)
+	}
+	if got := Result(7).String(); got != "Result(7)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestCheckRefs(t *testing.T) {
+	list := func(item string) string {
+		return `{"type": "object", "properties": {"item": ` + item + `, "next": {"$ref": "#/definitions/list"}}}`
+	}
+	integers := resolver(t, map[string]string{"list": list(`{"type": "integer"}`)})
+	numbers := resolver(t, map[string]string{"list": list(`{"type": "number"}`)})
+	ref := `{"$ref": "#/definitions/list"}`
+	for _, c := range []struct {
+		name       string
+		a, b       string
+		resolveA   Resolver
+		resolveB   Resolver
+		want       Result
+		wantString string
+	}{
+		{"same recursion", ref, ref, integers, integers, Compatible, "compatible"},
+		{"wider recursion", ref, ref, integers, numbers, Compatible, "compatible"},
+		{
+			"narrower recursion", ref, ref, numbers, integers, Incompatible,
+			"incompatible: /properties/item/type: values of type number are accepted (type)",
+		},
+		{"inline against recursion", list(`{"type": "integer"}`), ref, integers, numbers, Compatible, "compatible"},
+		{
+			"unresolved", `{"$ref": "#/definitions/missing"}`, ref, integers, integers, Unknown,
+			`unknown: /$ref: the reference "#/definitions/missing" cannot be resolved: no such definition ($ref)`,
+		},
+		{"not followed", ref, `{"type": "object"}`, nil, nil, Unknown, "unknown: /$ref: references are not followed ($ref)"},
+	} {
+		v := CheckRefs(schema(t, c.a), schema(t, c.b), c.resolveA, c.resolveB)
+		if v.Result != c.want || v.String() != c.wantString {
+			t.Errorf("%s: got %v, want %s", c.name, v, c.wantString)
+		}
+	}
+}
+
+func TestCheckDoesNotFollowRefs(t *testing.T) {
+	ref := `{"$ref": "#/definitions/list"}`
+	if v := Check(schema(t, ref), schema(t, ref)); v.Result != Compatible {
+		t.Errorf("identical references: got %v", v)
+	}
+	if v := Check(schema(t, ref), schema(t, `{"type": "object"}`)); v.Result != Unknown || v.Keyword != "$ref" {
+		t.Errorf("reference against a schema: got %v", v)
+	}
+}
@@ -0,0 +1,172 @@
+// Package convert moves OpenRPC documents between the generated specification packages.
+//
+// The 1.3 and 1.4 meta-schemas describe the same JSON shape apart from the "openrpc"
+// version: 1.4 only gives InfoObject and LinkObject implicit object types and titles the
+// content descriptor schema. Documents are therefore converted through their JSON form,
+// which every generated type round-trips exactly, including specification extensions,
+// and only the fields whose values differ between the versions are rewritten. The
+// converted document is compared with its source afterwards, so anything the target
+// types could not hold is reported as a Loss rather than dropped silently.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_3"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Change records a field whose value was rewritten during a conversion.
+type Change struct {
+	// Path is the JSON pointer of the field within the document.
+	Path    string
+	From    string
+	To      string
+	Message string
+}
+
+// Loss records a construct of the source document that the target version cannot express.
+type Loss struct {
+	// Path is the JSON pointer of the construct within the source document.
+	Path    string
+	Message string
+}
+
+// Report lists the changes made while converting a document and what was lost.
+type Report struct {
+	Changes []Change
+	Losses  []Loss
+}
+
+// Lossless reports whether the converted document carries everything of its source.
+func (r *Report) Lossless() bool {
+	return len(r.Losses) == 0
+}
+
+// Upgrade converts a 1.3 document to the 1.4 types, setting its "openrpc" field to
+// v1_4.LatestOpenrpc. That is the only value 1.4 requires to change, so it is the only
+// Change the report lists: every other field keeps its value, even where the 1.4 types
+// name it differently, such as the title of the InfoObject.
+func Upgrade(doc *v1_3.OpenrpcDocument) (*v1_4.OpenrpcDocument, *Report, error) {
+	if doc == nil {
+		return nil, nil, errors.New("convert: nil document")
+	}
+	to := string(v1_4.LatestOpenrpc)
+	var out v1_4.OpenrpcDocument
+	losses, err := convertJSON(doc, &out, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert: upgrading to %s: %w", to, err)
+	}
+	report := &Report{Changes: []Change{versionChange(doc.Openrpc, to)}, Losses: losses}
+	return &out, report, nil
+}
+
+// Downgrade converts a 1.4 document to the 1.3 types, setting its "openrpc" field to
+// the latest 1.3 release. Whatever the 1.3 types cannot hold is listed in the report's
+// Losses; callers decide whether the result is acceptable.
+func Downgrade(doc *v1_4.OpenrpcDocument) (*v1_3.OpenrpcDocument, *Report, error) {
+	if doc == nil {
+		return nil, nil, errors.New("convert: nil document")
+	}
+	to := string(v1_3.OpenrpcEnum0)
+	var out v1_3.OpenrpcDocument
+	losses, err := convertJSON(doc, &out, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("convert: downgrading to %s: %w", to, err)
+	}
+	report := &Report{Changes: []Change{versionChange(doc.Openrpc, to)}, Losses: losses}
+	return &out, report, nil
+}
+
+func versionChange[T ~string](from *T, to string) Change {
+	var version string
+	if from != nil {
+		version = string(*from)
+	}
+	return Change{
+		Path:    "/openrpc",
+		From:    version,
+		To:      to,
+		Message: "specification version set to " + to,
+	}
+}
+
+// convertJSON decodes the JSON form of from into to, replacing its "openrpc" field with
+// version, and returns whatever did not survive the trip.
+func convertJSON(from interface{}, to interface{}, version string) ([]Loss, error) {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["openrpc"], err = json.Marshal(version); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, to); err != nil {
+		return nil, err
+	}
+	converted, err := json.Marshal(to)
+	if err != nil {
+		return nil, err
+	}
+	var before, after interface{}
+	if err := decodeJSON(data, &before); err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(converted, &after); err != nil {
+		return nil, err
+	}
+	return compareJSON("", before, after, nil), nil
+}
+
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// compareJSON appends a Loss for every value of before that after does not reproduce.
+func compareJSON(path string, before, after interface{}, losses []Loss) []Loss {
+	switch s := before.(type) {
+	case map[string]interface{}:
+		t, ok := after.(map[string]interface{})
+		if !ok {
+			return append(losses, Loss{Path: path, Message: "object could not be represented"})
+		}
+		for _, key := range sorted.Keys(s) {
+			child := path + "/" + source.EscapeToken(key)
+			if _, ok := t[key]; !ok {
+				losses = append(losses, Loss{Path: child, Message: "field is not supported"})
+				continue
+			}
+			losses = compareJSON(child, s[key], t[key], losses)
+		}
+		return losses
+	case []interface{}:
+		t, ok := after.([]interface{})
+		if !ok || len(t) != len(s) {
+			return append(losses, Loss{Path: path, Message: "array could not be represented"})
+		}
+		for i := range s {
+			losses = compareJSON(fmt.Sprintf("%s/%d", path, i), s[i], t[i], losses)
+		}
+		return losses
+	default:
+		if !reflect.DeepEqual(before, after) {
+			return append(losses, Loss{Path: path, Message: fmt.Sprintf("value %v became %v", before, after)})
+		}
+		return losses
+	}
+}
@@ -0,0 +1,137 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_3"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// body is a document with every kind of object, holding extensions throughout, to
+// be completed with an "openrpc" field.
+const body = `
+	"info": {"title": "Petstore", "version": "1.0.0", "license": {"name": "MIT"}, "x-logo": "logo.png"},
+	"servers": [{"url": "https://{region}.example.com", "variables": {"region": {"default": "eu", "enum": ["eu", "us"]}}}],
+	"methods": [
+		{
+			"name": "get_pet",
+			"tags": [{"$ref": "#/components/tags/pets"}],
+			"params": [{"name": "id", "schema": {"$ref": "#/components/schemas/Id"}, "required": true}],
+			"result": {"name": "pet", "schema": {"properties": {"name": {"type": "string"}}, "type": ["object", "null"]}},
+			"errors": [{"code": 404, "message": "not found", "data": {"id": 7}}],
+			"links": [{"name": "owner", "method": "get_owner", "params": {"id": "$result.owner"}}],
+			"examples": [{"name": "fido", "params": [{"value": 7, "name": "id"}], "result": {"value": {"name": "Fido"}, "name": "pet"}}],
+			"x-rate-limit": 10
+		}
+	],
+	"components": {
+		"schemas": {"Id": {"minimum": 1, "type": "integer"}},
+		"tags": {"pets": {"name": "pets"}}
+	},
+	"x-audience": "public"
+}`
+
+func withVersion(version string) []byte {
+	return []byte(`{"openrpc": "` + version + `",` + body)
+}
+
+func compact(t *testing.T, data []byte) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestUpgrade(t *testing.T) {
+	var doc v1_3.OpenrpcDocument
+	if err := json.Unmarshal(withVersion("1.2.6"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	upgraded, report, err := Upgrade(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(upgraded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(out), compact(t, withVersion("1.4.0")); got != want {
+		t.Errorf("upgraded document\n got: %s\nwant: %s", got, want)
+	}
+	want := Change{Path: "/openrpc", From: "1.2.6", To: "1.4.0", Message: "specification version set to 1.4.0"}
+	if len(report.Changes) != 1 || report.Changes[0] != want {
+		t.Errorf("Changes = %+v, want only %+v", report.Changes, want)
+	}
+	if !report.Lossless() {
+		t.Errorf("Losses = %+v, want none", report.Losses)
+	}
+	if _, _, err := Upgrade(nil); err == nil {
+		t.Error("Upgrade(nil) succeeded")
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	var doc v1_4.OpenrpcDocument
+	if err := json.Unmarshal(withVersion("1.4.0"), &doc); err != nil {
+		t.Fatal(err)
+	}
+	downgraded, report, err := Downgrade(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(downgraded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(out), compact(t, withVersion("1.3.2")); got != want {
+		t.Errorf("downgraded document\n got: %s\nwant: %s", got, want)
+	}
+	want := Change{Path: "/openrpc", From: "1.4.0", To: "1.3.2", Message: "specification version set to 1.3.2"}
+	if len(report.Changes) != 1 || report.Changes[0] != want {
+		t.Errorf("Changes = %+v, want only %+v", report.Changes, want)
+	}
+	if len(report.Losses) != 0 || !report.Lossless() {
+		t.Errorf("Losses = %+v, want none for a full 1.4 document", report.Losses)
+	}
+	if _, _, err := Downgrade(nil); err == nil {
+		t.Error("Downgrade(nil) succeeded")
+	}
+}
+
+func TestConvertJSONReportsLosses(t *testing.T) {
+	// A target that only holds the version and the title loses everything else.
+	var narrow struct {
+		Openrpc string `json:"openrpc"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version int    `json:"version,string"`
+		} `json:"info"`
+		Servers []json.RawMessage `json:"servers,omitempty"`
+	}
+	from := json.RawMessage(`{"openrpc": "1.4.0", "info": {"title": "t", "version": "07", "x-a/b": 1}, "servers": [], "methods": []}`)
+	losses, err := convertJSON(from, &narrow, "1.3.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Loss{
+		{Path: "/info/version", Message: "value 07 became 7"},
+		{Path: "/info/x-a~1b", Message: "field is not supported"},
+		{Path: "/methods", Message: "field is not supported"},
+		{Path: "/servers", Message: "field is not supported"},
+	}
+	if len(losses) != len(want) {
+		t.Fatalf("losses = %+v, want %+v", losses, want)
+	}
+	for i := range want {
+		if losses[i] != want[i] {
+			t.Errorf("losses[%d] = %+v, want %+v", i, losses[i], want[i])
+		}
+	}
+	if (&Report{Losses: losses}).Lossless() {
+		t.Error("Lossless() with losses")
+	}
+}
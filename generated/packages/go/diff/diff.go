@@ -0,0 +1,381 @@
+// Package diff compares two versions of a 1.4 OpenRPC document, listing what changed
+// between them and how each change affects existing clients, and suggests the
+// semantic version bump the new version calls for.
+//
+// Methods are matched by name, params by name within a method and errors by code.
+// Both documents are dereferenced before being compared, so that moving a value into
+// the components is not a change; documents referring to other documents should be
+// dereferenced with a resolve.Resolver able to load them first. The references
+// dereferencing leaves in recursive schemas are followed in the document they belong to.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/compat"
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/resolve"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Class grades changes by their effect on existing clients.
+type Class int
+
+const (
+	// Informational changes do not affect what clients send or receive.
+	Informational Class = iota
+	// NonBreaking changes add to what clients may send or receive.
+	NonBreaking
+	// Breaking changes can make requests of existing clients fail, or send them
+	// responses they do not expect.
+	Breaking
+)
+
+func (c Class) String() string {
+	switch c {
+	case Informational:
+		return "informational"
+	case NonBreaking:
+		return "non-breaking"
+	case Breaking:
+		return "breaking"
+	}
+	return "Class(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Type identifies the kind of a change.
+type Type string
+
+const (
+	MethodAdded           Type = "method-added"
+	MethodRemoved         Type = "method-removed"
+	MethodDeprecated      Type = "method-deprecated"
+	MethodUndeprecated    Type = "method-undeprecated"
+	MethodDocsChanged     Type = "method-docs-changed"
+	ParamStructureChanged Type = "param-structure-changed"
+	ParamAdded            Type = "param-added"
+	ParamRemoved          Type = "param-removed"
+	ParamMoved            Type = "param-moved"
+	ParamBecameRequired   Type = "param-became-required"
+	ParamBecameOptional   Type = "param-became-optional"
+	ParamDeprecated       Type = "param-deprecated"
+	ParamSchemaChanged    Type = "param-schema-changed"
+	ResultAdded           Type = "result-added"
+	ResultRemoved         Type = "result-removed"
+	ResultSchemaChanged   Type = "result-schema-changed"
+	ErrorAdded            Type = "error-added"
+	ErrorRemoved          Type = "error-removed"
+	ErrorMessageChanged   Type = "error-message-changed"
+)
+
+// Change is a difference between two versions of a document.
+type Change struct {
+	Type  Type
+	Class Class
+	// Path is the JSON pointer of the changed value within the new document, or
+	// within the old one for values that were removed.
+	Path    string
+	Message string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", c.Path, c.Class, c.Message, c.Type)
+}
+
+// Bump is a semantic version increment.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpNone:
+		return "none"
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "Bump(" + strconv.Itoa(int(b)) + ")"
+}
+
+// Suggest returns the version bump changes call for: major for breaking changes,
+// minor for non-breaking ones, patch for informational ones and none without changes.
+func Suggest(changes []Change) Bump {
+	bump := BumpNone
+	for _, c := range changes {
+		var b Bump
+		switch c.Class {
+		case Breaking:
+			b = BumpMajor
+		case NonBreaking:
+			b = BumpMinor
+		default:
+			b = BumpPatch
+		}
+		if b > bump {
+			bump = b
+		}
+	}
+	return bump
+}
+
+// Compare returns the changes from old to new, method by method in the order of the
+// new document, followed by the methods that were removed.
+func Compare(old, new *v1_4.OpenrpcDocument) ([]Change, error) {
+	oldResolver, newResolver := resolve.New(old), resolve.New(new)
+	oldDoc, err := oldResolver.Dereference(resolve.DereferenceOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("dereferencing the old document: %w", err)
+	}
+	newDoc, err := newResolver.Dereference(resolve.DereferenceOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("dereferencing the new document: %w", err)
+	}
+	c := &comparer{old: schemaResolver(oldResolver), new: schemaResolver(newResolver)}
+	oldMethods := methods(oldDoc)
+	seen := map[string]bool{}
+	for _, m := range methods(newDoc) {
+		seen[m.GetName()] = true
+		if o, ok := find(oldMethods, m.GetName()); ok {
+			c.method(o, m)
+			continue
+		}
+		c.add(MethodAdded, NonBreaking, m.path, "method %q was added", m.GetName())
+	}
+	for _, o := range oldMethods {
+		if !seen[o.GetName()] {
+			c.add(MethodRemoved, Breaking, o.path, "method %q was removed", o.GetName())
+		}
+	}
+	return c.changes, nil
+}
+
+// method is a method of a dereferenced document along with its JSON pointer.
+type method struct {
+	path string
+	*v1_4.MethodObject
+}
+
+func methods(doc *v1_4.OpenrpcDocument) []method {
+	var methods []method
+	if doc.Methods != nil {
+		for i, m := range *doc.Methods {
+			if value := m.Value(); value != nil {
+				methods = append(methods, method{path: "/methods/" + strconv.Itoa(i), MethodObject: value})
+			}
+		}
+	}
+	return methods
+}
+
+func find(methods []method, name string) (method, bool) {
+	for _, m := range methods {
+		if m.GetName() == name {
+			return m, true
+		}
+	}
+	return method{}, false
+}
+
+type comparer struct {
+	// old and new resolve the references left in the schemas of each document.
+	old, new compat.Resolver
+	changes  []Change
+}
+
+func (c *comparer) add(t Type, class Class, path, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{Type: t, Class: class, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *comparer) method(old, new method) {
+	name := new.GetName()
+	switch {
+	case !old.IsDeprecated() && new.IsDeprecated():
+		c.add(MethodDeprecated, Informational, new.path+"/deprecated", "method %q was deprecated", name)
+	case old.IsDeprecated() && !new.IsDeprecated():
+		c.add(MethodUndeprecated, Informational, fieldPath(old.path, new.path, "deprecated", new.Deprecated != nil), "method %q is no longer deprecated", name)
+	}
+	if old.GetSummary() != new.GetSummary() || old.GetDescription() != new.GetDescription() {
+		c.add(MethodDocsChanged, Informational, new.path, "the summary or description of method %q changed", name)
+	}
+	if before, after := old.GetParamStructure(), new.GetParamStructure(); before != after {
+		class := Breaking
+		if after == string(v1_4.MethodObjectParamStructureEnum2) {
+			class = NonBreaking
+		}
+		c.add(ParamStructureChanged, class, new.path+"/paramStructure", "the param structure of method %q changed from %q to %q", name, before, after)
+	}
+	c.params(old, new)
+	c.result(old, new)
+	c.errors(old, new)
+}
+
+// param is a param of a method along with its JSON pointer and position.
+type param struct {
+	path  string
+	index int
+	*v1_4.ContentDescriptorObject
+}
+
+func params(m method) map[string]param {
+	params := map[string]param{}
+	if m.Params != nil {
+		for j, p := range *m.Params {
+			if value := p.Value(); value != nil {
+				params[value.GetName()] = param{path: m.path + "/params/" + strconv.Itoa(j), index: j, ContentDescriptorObject: value}
+			}
+		}
+	}
+	return params
+}
+
+func (c *comparer) params(oldMethod, newMethod method) {
+	name := newMethod.GetName()
+	// Params are sent by position unless the method takes them by name only.
+	byPosition := newMethod.GetParamStructure() != string(v1_4.MethodObjectParamStructureEnum1)
+	oldParams, newParams := params(oldMethod), params(newMethod)
+	for _, key := range sortedParams(newParams) {
+		p := newParams[key]
+		o, ok := oldParams[key]
+		if !ok {
+			if p.IsRequired() {
+				c.add(ParamAdded, Breaking, p.path, "required param %q was added to method %q", key, name)
+			} else {
+				c.add(ParamAdded, NonBreaking, p.path, "optional param %q was added to method %q", key, name)
+			}
+			continue
+		}
+		if byPosition && o.index != p.index {
+			c.add(ParamMoved, Breaking, p.path, "param %q of method %q moved from position %d to %d", key, name, o.index, p.index)
+		}
+		switch {
+		case !o.IsRequired() && p.IsRequired():
+			c.add(ParamBecameRequired, Breaking, p.path+"/required", "param %q of method %q became required", key, name)
+		case o.IsRequired() && !p.IsRequired():
+			c.add(ParamBecameOptional, NonBreaking, fieldPath(o.path, p.path, "required", p.Required != nil), "param %q of method %q became optional", key, name)
+		}
+		if !o.IsDeprecated() && p.IsDeprecated() {
+			c.add(ParamDeprecated, Informational, p.path+"/deprecated", "param %q of method %q was deprecated", key, name)
+		}
+		// Clients send params, so they break when the schema accepts fewer values.
+		switch kept, added := c.compareSchemas(o.Schema, p.Schema); {
+		case kept.Result == compat.Compatible && added.Result == compat.Compatible:
+		case kept.Result == compat.Compatible:
+			c.add(ParamSchemaChanged, NonBreaking, p.path+"/schema"+added.Path, "the schema of param %q of method %q accepts more values (%s)", key, name, added.Keyword)
+		case kept.Result == compat.Incompatible:
+			c.add(ParamSchemaChanged, Breaking, p.path+"/schema"+kept.Path, "the schema of param %q of method %q no longer accepts some values (%s)", key, name, kept.Keyword)
+		default:
+			c.add(ParamSchemaChanged, Breaking, p.path+"/schema"+kept.Path, "the schema of param %q of method %q may no longer accept some values (%s)", key, name, kept.Keyword)
+		}
+	}
+	for _, key := range sortedParams(oldParams) {
+		if _, ok := newParams[key]; !ok {
+			c.add(ParamRemoved, Breaking, oldParams[key].path, "param %q was removed from method %q", key, name)
+		}
+	}
+}
+
+func (c *comparer) result(oldMethod, newMethod method) {
+	name := newMethod.GetName()
+	path := newMethod.path + "/result"
+	var old, new *v1_4.ContentDescriptorObject
+	if oldMethod.Result != nil {
+		old = oldMethod.Result.Value()
+	}
+	if newMethod.Result != nil {
+		new = newMethod.Result.Value()
+	}
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.add(ResultAdded, Breaking, path, "method %q now has a result, so it is no longer a notification", name)
+		return
+	case new == nil:
+		c.add(ResultRemoved, Breaking, oldMethod.path+"/result", "method %q no longer has a result", name)
+		return
+	}
+	// Clients receive results, so they break when the schema accepts more values.
+	switch kept, added := c.compareSchemas(old.Schema, new.Schema); {
+	case kept.Result == compat.Compatible && added.Result == compat.Compatible:
+	case added.Result == compat.Compatible:
+		c.add(ResultSchemaChanged, NonBreaking, path+"/schema"+kept.Path, "the result schema of method %q accepts fewer values (%s)", name, kept.Keyword)
+	case added.Result == compat.Incompatible:
+		c.add(ResultSchemaChanged, Breaking, path+"/schema"+added.Path, "the result schema of method %q accepts more values (%s)", name, added.Keyword)
+	default:
+		c.add(ResultSchemaChanged, Breaking, path+"/schema"+added.Path, "the result schema of method %q may accept more values (%s)", name, added.Keyword)
+	}
+}
+
+// errorObject is an error of a method along with its JSON pointer.
+type errorObject struct {
+	path string
+	*v1_4.ErrorObject
+}
+
+func errorsByCode(m method) (map[int64]errorObject, []int64) {
+	errs := map[int64]errorObject{}
+	var codes []int64
+	if m.Errors != nil {
+		for j, e := range *m.Errors {
+			if value := e.Value(); value != nil && value.Code != nil {
+				code := int64(*value.Code)
+				if _, ok := errs[code]; !ok {
+					codes = append(codes, code)
+				}
+				errs[code] = errorObject{path: m.path + "/errors/" + strconv.Itoa(j), ErrorObject: value}
+			}
+		}
+	}
+	return errs, codes
+}
+
+func (c *comparer) errors(oldMethod, newMethod method) {
+	name := newMethod.GetName()
+	oldErrors, oldCodes := errorsByCode(oldMethod)
+	newErrors, newCodes := errorsByCode(newMethod)
+	for _, code := range newCodes {
+		e := newErrors[code]
+		o, ok := oldErrors[code]
+		switch {
+		case !ok:
+			c.add(ErrorAdded, NonBreaking, e.path, "error %d was added to method %q", code, name)
+		case deref.String(o.Message) != deref.String(e.Message):
+			c.add(ErrorMessageChanged, Informational, e.path+"/message", "the message of error %d of method %q changed", code, name)
+		}
+	}
+	for _, code := range oldCodes {
+		if _, ok := newErrors[code]; !ok {
+			c.add(ErrorRemoved, Breaking, oldErrors[code].path, "error %d was removed from method %q", code, name)
+		}
+	}
+}
+
+// fieldPath returns the JSON pointer of the field of a changed value: within the new
+// document when the new value has the field, and within the old one when it was removed.
+func fieldPath(oldPath, newPath, field string, present bool) string {
+	if present {
+		return newPath + "/" + field
+	}
+	return oldPath + "/" + field
+}
+
+// sortedParams returns the names of params in the order they are declared.
+func sortedParams(params map[string]param) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return params[names[i]].index < params[names[j]].index })
+	return names
+}
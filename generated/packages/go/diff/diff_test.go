@@ -0,0 +1,180 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+func decode(t *testing.T, data string) *v1_4.OpenrpcDocument {
+	t.Helper()
+	var doc v1_4.OpenrpcDocument
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return &doc
+}
+
+const oldDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Diff", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "a",
+			"paramStructure": "by-name",
+			"params": [
+				{"name": "x", "schema": {"type": "string"}},
+				{"name": "y", "schema": {"$ref": "#/components/schemas/S"}},
+				{"name": "w", "required": true, "schema": {}}
+			],
+			"result": {"name": "r", "schema": {"type": ["string", "number"]}},
+			"errors": [{"code": 1, "message": "one"}, {"code": 2, "message": "two"}]
+		},
+		{"name": "gone", "params": []},
+		{
+			"name": "same",
+			"deprecated": true,
+			"params": [{"name": "p", "schema": {"type": "object", "required": ["a"], "properties": {"a": {"type": "integer"}}}}],
+			"result": {"name": "r", "schema": {"type": "object", "properties": {"n": {"type": "integer"}}}}
+		}
+	],
+	"components": {"schemas": {"S": {"enum": [1, 2]}}}
+}`
+
+const newDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Diff", "version": "2.0.0"},
+	"methods": [
+		{
+			"name": "a",
+			"paramStructure": "by-position",
+			"deprecated": true,
+			"params": [
+				{"name": "y", "required": true, "schema": {"enum": [1, 2, 3]}},
+				{"name": "x", "schema": {"type": "string", "minLength": 2}},
+				{"name": "w", "schema": {}},
+				{"name": "z", "schema": {}}
+			],
+			"result": {"name": "r", "schema": {"type": "string"}},
+			"errors": [{"$ref": "#/components/errors/One"}, {"code": 3, "message": "three"}]
+		},
+		{
+			"name": "same",
+			"params": [{"name": "p", "schema": {"type": "object", "properties": {"a": {"type": "number", "title": "A"}}}}],
+			"result": {"name": "r", "schema": {"type": "object", "properties": {"n": {"type": "number"}}}}
+		},
+		{"name": "new", "params": []}
+	],
+	"components": {"errors": {"One": {"code": 1, "message": "uno"}}}
+}`
+
+func changes(t *testing.T, old, new string) []string {
+	t.Helper()
+	changes, err := Compare(decode(t, old), decode(t, new))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	return got
+}
+
+func TestCompare(t *testing.T) {
+	got := changes(t, oldDoc, newDoc)
+	want := []string{
+		`/methods/0/deprecated: informational: method "a" was deprecated [method-deprecated]`,
+		`/methods/0/paramStructure: breaking: the param structure of method "a" changed from "by-name" to "by-position" [param-structure-changed]`,
+		`/methods/0/params/0: breaking: param "y" of method "a" moved from position 1 to 0 [param-moved]`,
+		`/methods/0/params/0/required: breaking: param "y" of method "a" became required [param-became-required]`,
+		`/methods/0/params/0/schema/enum: non-breaking: the schema of param "y" of method "a" accepts more values (enum) [param-schema-changed]`,
+		`/methods/0/params/1: breaking: param "x" of method "a" moved from position 0 to 1 [param-moved]`,
+		`/methods/0/params/1/schema/minLength: breaking: the schema of param "x" of method "a" no longer accepts some values (minLength) [param-schema-changed]`,
+		// The required field was removed, so it is located in the old document.
+		`/methods/0/params/2/required: non-breaking: param "w" of method "a" became optional [param-became-optional]`,
+		`/methods/0/params/3: non-breaking: optional param "z" was added to method "a" [param-added]`,
+		`/methods/0/result/schema/type: non-breaking: the result schema of method "a" accepts fewer values (type) [result-schema-changed]`,
+		`/methods/0/errors/0/message: informational: the message of error 1 of method "a" changed [error-message-changed]`,
+		`/methods/0/errors/1: non-breaking: error 3 was added to method "a" [error-added]`,
+		`/methods/0/errors/1: breaking: error 2 was removed from method "a" [error-removed]`,
+		`/methods/2/deprecated: informational: method "same" is no longer deprecated [method-undeprecated]`,
+		`/methods/1/params/0/schema/required: non-breaking: the schema of param "p" of method "same" accepts more values (required) [param-schema-changed]`,
+		`/methods/1/result/schema/properties/n/type: breaking: the result schema of method "same" accepts more values (type) [result-schema-changed]`,
+		`/methods/2: non-breaking: method "new" was added [method-added]`,
+		`/methods/1: breaking: method "gone" was removed [method-removed]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	if got := changes(t, oldDoc, oldDoc); len(got) != 0 {
+		t.Errorf("changes = %q, want none", got)
+	}
+}
+
+// recursive returns a document whose method returns a recursive schema, with the
+// value of each node being of the given type and the node named after name.
+func recursive(name, valueType string) string {
+	return `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Tree", "version": "1.0.0"},
+		"methods": [{"name": "tree", "params": [], "result": {"name": "root", "schema": {"$ref": "#/components/schemas/` + name + `"}}}],
+		"components": {"schemas": {"` + name + `": {
+			"type": "object",
+			"properties": {
+				"value": {"type": "` + valueType + `"},
+				"children": {"type": "array", "items": {"$ref": "#/components/schemas/` + name + `"}}
+			}
+		}}}
+	}`
+}
+
+func TestCompareRecursive(t *testing.T) {
+	// Changes are found through the first property, which leads back into the node, and
+	// are located as if the node replaced the reference to it.
+	for _, c := range []struct {
+		name     string
+		old, new string
+		want     []string
+	}{
+		{"unchanged", recursive("Node", "integer"), recursive("Node", "integer"), nil},
+		{"renamed", recursive("Node", "integer"), recursive("Tree", "integer"), nil},
+		{
+			"narrowed", recursive("Node", "number"), recursive("Tree", "integer"),
+			[]string{`/methods/0/result/schema/properties/children/items/properties/value/type: non-breaking: the result schema of method "tree" accepts fewer values (type) [result-schema-changed]`},
+		},
+		{
+			"widened", recursive("Node", "integer"), recursive("Node", "number"),
+			[]string{`/methods/0/result/schema/properties/children/items/properties/value/type: breaking: the result schema of method "tree" accepts more values (type) [result-schema-changed]`},
+		},
+	} {
+		if got := changes(t, c.old, c.new); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: changes = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	for _, c := range []struct {
+		classes []Class
+		want    Bump
+	}{
+		{nil, BumpNone},
+		{[]Class{Informational}, BumpPatch},
+		{[]Class{Informational, NonBreaking}, BumpMinor},
+		{[]Class{Breaking, NonBreaking}, BumpMajor},
+	} {
+		var changes []Change
+		for _, class := range c.classes {
+			changes = append(changes, Change{Class: class})
+		}
+		if got := Suggest(changes); got != c.want {
+			t.Errorf("Suggest(%v) = %v, want %v", c.classes, got, c.want)
+		}
+	}
+}
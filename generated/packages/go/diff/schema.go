@@ -0,0 +1,32 @@
+package diff
+
+import (
+	"github.com/zcstarr/spec-types/generated/packages/go/compat"
+	"github.com/zcstarr/spec-types/generated/packages/go/resolve"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// compareSchemas compares the schemas of two versions of a content descriptor. Kept
+// tells whether the new schema accepts every value the old one does, and added
+// whether the old one accepts every value the new one does. The paths of both
+// verdicts follow the structure the schemas share, locating the change in either.
+func (c *comparer) compareSchemas(old, new *v1_4.ContentDescriptorObjectSchema) (kept, added compat.Verdict) {
+	a, b := schema(old), schema(new)
+	return compat.CheckRefs(a, b, c.old, c.new), compat.CheckRefs(b, a, c.new, c.old)
+}
+
+func schema(s *v1_4.ContentDescriptorObjectSchema) *v1_4.JSONSchema {
+	if s == nil {
+		return nil
+	}
+	converted := v1_4.JSONSchema(*s)
+	return &converted
+}
+
+// schemaResolver returns a compat.Resolver looking schemas up with r.
+func schemaResolver(r *resolve.Resolver) compat.Resolver {
+	return func(ref string) (*v1_4.JSONSchema, error) {
+		target := v1_4.Ref(ref)
+		return r.Schema(&v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{Ref: &target}})
+	}
+}
@@ -0,0 +1,10 @@
+// Package deref reads the optional fields of the generated types, which are all pointers.
+package deref
+
+// String returns the string p points to, or "" when p is nil.
+func String[T ~string](p *T) string {
+	if p == nil {
+		return ""
+	}
+	return string(*p)
+}
@@ -0,0 +1,15 @@
+// Package sorted iterates over maps in a stable order, so that reports list their
+// findings the same way on every run.
+package sorted
+
+import "sort"
+
+// Keys returns the keys of m in increasing order.
+func Keys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+// Package jsonschema validates JSON values against the draft-07 JSON Schemas modelled
+// by v1_4.JSONSchema, implementing every assertion keyword of v1_4.JSONSchemaObject
+// along with boolean schemas and "$ref"s within and between registered schemas.
+//
+// Values are the result of decoding JSON into an interface{}: nil, bool, string,
+// float64 or json.Number, []interface{} and map[string]interface{}. Decoding with
+// json.Decoder.UseNumber keeps numbers exact. Patterns are matched with the regexp
+// package, whose RE2 syntax differs from ECMA 262 for backreferences and lookarounds.
+// The format keyword is treated as an annotation and not asserted.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sync"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Error describes a value that does not satisfy a keyword of its schema.
+type Error struct {
+	// InstancePath is the JSON pointer of the invalid value within the instance.
+	InstancePath string
+	// SchemaPath is the JSON pointer of the failed keyword within the schema, with
+	// every "$ref" crossed on the way to it.
+	SchemaPath string
+	Keyword    string
+	Message    string
+	// Causes are the errors of the subschemas that led to this one failing, such as
+	// those of every anyOf and oneOf alternative.
+	Causes []Error
+}
+
+func (e Error) Error() string {
+	path := e.InstancePath
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s (%s)", path, e.Message, e.Keyword)
+}
+
+// Validator validates values against a schema. It is safe for concurrent use.
+type Validator struct {
+	root *v1_4.JSONSchema
+	base *url.URL
+
+	mu        sync.Mutex
+	resources map[string]resource
+	schemas   map[string]target
+	patterns  map[string]*regexp.Regexp
+}
+
+// Option configures a Validator.
+type Option func(*Validator) error
+
+// WithBase sets the URI of the schema, against which its references resolve when it
+// does not declare an "$id".
+func WithBase(base *url.URL) Option {
+	return func(v *Validator) error {
+		v.base = base
+		return nil
+	}
+}
+
+// WithSchema makes schema available to references under uri, along with the schemas
+// it identifies with "$id".
+func WithSchema(uri string, schema *v1_4.JSONSchema) Option {
+	return func(v *Validator) error {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return err
+		}
+		return v.addResource(normalize(u), schema)
+	}
+}
+
+// New returns a Validator for schema. The draft-07 meta-schema is always available to
+// references, under MetaSchemaID as well as "http://json-schema.org/draft-07/schema".
+func New(schema *v1_4.JSONSchema, options ...Option) (*Validator, error) {
+	v := &Validator{
+		root:      schema,
+		base:      &url.URL{},
+		resources: map[string]resource{},
+		schemas:   map[string]target{},
+		patterns:  map[string]*regexp.Regexp{},
+	}
+	var meta v1_4.JSONSchema
+	if err := json.Unmarshal([]byte(MetaSchema), &meta); err != nil {
+		return nil, err
+	}
+	for _, uri := range []string{MetaSchemaID, "http://json-schema.org/draft-07/schema"} {
+		if err := WithSchema(uri, &meta)(v); err != nil {
+			return nil, err
+		}
+	}
+	for _, option := range options {
+		if err := option(v); err != nil {
+			return nil, err
+		}
+	}
+	v.base = normalize(v.base)
+	if err := v.addResource(v.base, schema); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Validate returns every error of value against the schema, or none when it is valid.
+func (v *Validator) Validate(value interface{}) []Error {
+	e := &evaluation{v: v, active: map[string]bool{}}
+	return e.validate(v.root, v.base, value, "", "")
+}
+
+// ValidateJSON decodes data, keeping numbers exact, and validates it.
+func (v *Validator) ValidateJSON(data []byte) ([]Error, error) {
+	value, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return v.Validate(value), nil
+}
+
+// Decode decodes a single JSON value, keeping numbers as json.Number.
+func Decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return value, nil
+}
@@ -0,0 +1,513 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// evaluation validates one instance. Active holds the references being followed for
+// each instance location, to stop references that lead back to themselves.
+type evaluation struct {
+	v      *Validator
+	active map[string]bool
+}
+
+func (e *evaluation) validate(s *v1_4.JSONSchema, base *url.URL, value interface{}, instancePath, schemaPath string) []Error {
+	if s == nil {
+		return nil
+	}
+	if s.JSONSchemaBoolean != nil {
+		if *s.JSONSchemaBoolean {
+			return nil
+		}
+		return []Error{{InstancePath: instancePath, SchemaPath: schemaPath, Keyword: "false", Message: "no value is allowed"}}
+	}
+	o := s.JSONSchemaObject
+	if o == nil {
+		return nil
+	}
+	if o.Ref != nil {
+		return e.ref(string(*o.Ref), base, value, instancePath, schemaPath+"/$ref")
+	}
+	f := &frame{e: e, base: schemaBase(base, o), o: o, value: value, instancePath: instancePath, schemaPath: schemaPath}
+	checkType(f)
+	checkEnum(f)
+	checkConst(f)
+	checkNumber(f)
+	checkString(f)
+	checkArray(f)
+	checkObject(f)
+	checkCombinators(f)
+	checkConditional(f)
+	return f.errs
+}
+
+// ref validates value against the schema ref names. A reference that is followed
+// again for the same instance location without consuming any of it never ends, so it
+// fails instead.
+func (e *evaluation) ref(ref string, base *url.URL, value interface{}, instancePath, schemaPath string) []Error {
+	t, uri, err := e.v.resolve(base, ref)
+	if err != nil {
+		return []Error{{InstancePath: instancePath, SchemaPath: schemaPath, Keyword: "$ref", Message: fmt.Sprintf("cannot resolve %q: %v", ref, err)}}
+	}
+	key := uri + " " + instancePath
+	if e.active[key] {
+		return []Error{{InstancePath: instancePath, SchemaPath: schemaPath, Keyword: "$ref", Message: fmt.Sprintf("reference cycle through %q", ref)}}
+	}
+	e.active[key] = true
+	defer delete(e.active, key)
+	return e.validate(t.schema, t.base, value, instancePath, schemaPath)
+}
+
+// frame holds the evaluation of one schema object against one value.
+type frame struct {
+	e            *evaluation
+	base         *url.URL
+	o            *v1_4.JSONSchemaObject
+	value        interface{}
+	instancePath string
+	schemaPath   string
+	errs         []Error
+}
+
+func (f *frame) fail(keyword string, causes []Error, format string, args ...interface{}) {
+	f.errs = append(f.errs, Error{
+		InstancePath: f.instancePath,
+		SchemaPath:   f.schemaPath + "/" + keyword,
+		Keyword:      keyword,
+		Message:      fmt.Sprintf(format, args...),
+		Causes:       causes,
+	})
+}
+
+// sub validates a value nested in f's value, at the given token, against a subschema
+// found at the given schema tokens below f's schema.
+func (f *frame) sub(s *v1_4.JSONSchema, value interface{}, token *string, schemaTokens ...string) []Error {
+	instancePath := f.instancePath
+	if token != nil {
+		instancePath += "/" + source.EscapeToken(*token)
+	}
+	schemaPath := f.schemaPath
+	for _, t := range schemaTokens {
+		schemaPath += "/" + source.EscapeToken(t)
+	}
+	return f.e.validate(s, f.base, value, instancePath, schemaPath)
+}
+
+func checkType(f *frame) {
+	if f.o.Type == nil {
+		return
+	}
+	var types []string
+	if f.o.Type.SimpleTypes != nil {
+		types = append(types, string(*f.o.Type.SimpleTypes))
+	}
+	if f.o.Type.ArrayOfSimpleTypes != nil {
+		for _, t := range *f.o.Type.ArrayOfSimpleTypes {
+			types = append(types, string(t))
+		}
+	}
+	for _, t := range types {
+		if hasType(f.value, t) {
+			return
+		}
+	}
+	f.fail("type", nil, "expected %s, got %s", strings.Join(types, " or "), typeOf(f.value))
+}
+
+func checkEnum(f *frame) {
+	if f.o.Enum == nil {
+		return
+	}
+	for _, allowed := range *f.o.Enum {
+		if equal(f.value, allowed) {
+			return
+		}
+	}
+	f.fail("enum", nil, "value must be one of %s", describe(*f.o.Enum))
+}
+
+func checkConst(f *frame) {
+	if len(f.o.Const) == 0 {
+		return
+	}
+	allowed, err := Decode(f.o.Const)
+	if err != nil || !equal(f.value, allowed) {
+		f.fail("const", nil, "value must be %s", describe([]v1_4.AlwaysTrue{allowed}))
+	}
+}
+
+func checkNumber(f *frame) {
+	n, ok := toRat(f.value)
+	if !ok {
+		return
+	}
+	if f.o.MultipleOf != nil {
+		if m := floatRat(float64(*f.o.MultipleOf)); m.Sign() > 0 && !new(big.Rat).Quo(n, m).IsInt() {
+			f.fail("multipleOf", nil, "%v is not a multiple of %v", f.value, *f.o.MultipleOf)
+		}
+	}
+	if f.o.Maximum != nil && n.Cmp(floatRat(float64(*f.o.Maximum))) > 0 {
+		f.fail("maximum", nil, "%v is greater than the maximum of %v", f.value, *f.o.Maximum)
+	}
+	if f.o.ExclusiveMaximum != nil && n.Cmp(floatRat(float64(*f.o.ExclusiveMaximum))) >= 0 {
+		f.fail("exclusiveMaximum", nil, "%v is not less than %v", f.value, *f.o.ExclusiveMaximum)
+	}
+	if f.o.Minimum != nil && n.Cmp(floatRat(float64(*f.o.Minimum))) < 0 {
+		f.fail("minimum", nil, "%v is less than the minimum of %v", f.value, *f.o.Minimum)
+	}
+	if f.o.ExclusiveMinimum != nil && n.Cmp(floatRat(float64(*f.o.ExclusiveMinimum))) <= 0 {
+		f.fail("exclusiveMinimum", nil, "%v is not greater than %v", f.value, *f.o.ExclusiveMinimum)
+	}
+}
+
+func checkString(f *frame) {
+	s, ok := f.value.(string)
+	if !ok {
+		return
+	}
+	length := utf8.RuneCountInString(s)
+	if f.o.MaxLength != nil && int64(length) > int64(*f.o.MaxLength) {
+		f.fail("maxLength", nil, "string is longer than %d characters", *f.o.MaxLength)
+	}
+	if f.o.MinLength != nil && int64(length) < int64(*f.o.MinLength) {
+		f.fail("minLength", nil, "string is shorter than %d characters", *f.o.MinLength)
+	}
+	if f.o.Pattern != nil {
+		re, err := f.e.v.pattern(string(*f.o.Pattern))
+		switch {
+		case err != nil:
+			f.fail("pattern", nil, "invalid pattern %q: %v", *f.o.Pattern, err)
+		case !re.MatchString(s):
+			f.fail("pattern", nil, "string does not match the pattern %q", *f.o.Pattern)
+		}
+	}
+}
+
+func checkArray(f *frame) {
+	array, ok := f.value.([]interface{})
+	if !ok {
+		return
+	}
+	if items := f.o.Items; items != nil {
+		switch {
+		case items.JSONSchema != nil:
+			for i, item := range array {
+				token := strconv.Itoa(i)
+				f.errs = append(f.errs, f.sub(items.JSONSchema, item, &token, "items")...)
+			}
+		case items.SchemaArray != nil:
+			schemas := *items.SchemaArray
+			for i, item := range array {
+				token := strconv.Itoa(i)
+				if i < len(schemas) {
+					f.errs = append(f.errs, f.sub(&schemas[i], item, &token, "items", token)...)
+				} else if b := f.o.AdditionalItems; b != nil && b.JSONSchemaBoolean != nil && !bool(*b.JSONSchemaBoolean) {
+					f.fail("additionalItems", nil, "array has more than the %d items allowed", len(schemas))
+					break
+				} else if f.o.AdditionalItems != nil {
+					f.errs = append(f.errs, f.sub(f.o.AdditionalItems, item, &token, "additionalItems")...)
+				}
+			}
+		}
+	}
+	if f.o.MaxItems != nil && int64(len(array)) > int64(*f.o.MaxItems) {
+		f.fail("maxItems", nil, "array has more than %d items", *f.o.MaxItems)
+	}
+	if f.o.MinItems != nil && int64(len(array)) < int64(*f.o.MinItems) {
+		f.fail("minItems", nil, "array has fewer than %d items", *f.o.MinItems)
+	}
+	if f.o.UniqueItems != nil && bool(*f.o.UniqueItems) {
+	unique:
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if equal(array[i], array[j]) {
+					f.fail("uniqueItems", nil, "items %d and %d are equal", i, j)
+					break unique
+				}
+			}
+		}
+	}
+	if f.o.Contains != nil {
+		var causes []Error
+		for i, item := range array {
+			token := strconv.Itoa(i)
+			errs := f.sub(f.o.Contains, item, &token, "contains")
+			if len(errs) == 0 {
+				return
+			}
+			causes = append(causes, errs...)
+		}
+		f.fail("contains", causes, "no item matches the contains schema")
+	}
+}
+
+func checkObject(f *frame) {
+	object, ok := f.value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if f.o.MaxProperties != nil && int64(len(object)) > int64(*f.o.MaxProperties) {
+		f.fail("maxProperties", nil, "object has more than %d properties", *f.o.MaxProperties)
+	}
+	if f.o.MinProperties != nil && int64(len(object)) < int64(*f.o.MinProperties) {
+		f.fail("minProperties", nil, "object has fewer than %d properties", *f.o.MinProperties)
+	}
+	if f.o.Required != nil {
+		for _, name := range *f.o.Required {
+			if _, ok := object[string(name)]; !ok {
+				f.fail("required", nil, "missing required property %q", name)
+			}
+		}
+	}
+	for _, name := range names {
+		name := name
+		value := object[name]
+		matched := false
+		if f.o.Properties != nil {
+			if s, ok := (*f.o.Properties)[name]; ok {
+				matched = true
+				f.errs = append(f.errs, f.sub(&s, value, &name, "properties", name)...)
+			}
+		}
+		if f.o.PatternProperties != nil {
+			for _, pattern := range sorted.Keys(*f.o.PatternProperties) {
+				re, err := f.e.v.pattern(pattern)
+				if err != nil {
+					f.fail("patternProperties", nil, "invalid pattern %q: %v", pattern, err)
+					continue
+				}
+				if re.MatchString(name) {
+					matched = true
+					s := (*f.o.PatternProperties)[pattern]
+					f.errs = append(f.errs, f.sub(&s, value, &name, "patternProperties", pattern)...)
+				}
+			}
+		}
+		if !matched && f.o.AdditionalProperties != nil {
+			if b := f.o.AdditionalProperties.JSONSchemaBoolean; b != nil && !bool(*b) {
+				f.fail("additionalProperties", nil, "additional property %q is not allowed", name)
+			} else {
+				f.errs = append(f.errs, f.sub(f.o.AdditionalProperties, value, &name, "additionalProperties")...)
+			}
+		}
+		if f.o.PropertyNames != nil {
+			if causes := f.e.validate(f.o.PropertyNames, f.base, name, f.instancePath, f.schemaPath+"/propertyNames"); len(causes) > 0 {
+				f.fail("propertyNames", causes, "property name %q is not valid", name)
+			}
+		}
+		if f.o.Dependencies != nil {
+			dependency, ok := (*f.o.Dependencies)[name]
+			switch {
+			case !ok:
+			case dependency.StringArray != nil:
+				for _, required := range *dependency.StringArray {
+					if _, ok := object[string(required)]; !ok {
+						f.fail("dependencies", nil, "property %q requires property %q", name, required)
+					}
+				}
+			case dependency.JSONSchema != nil:
+				if causes := f.sub(dependency.JSONSchema, f.value, nil, "dependencies", name); len(causes) > 0 {
+					f.fail("dependencies", causes, "property %q requires the object to match its dependency schema", name)
+				}
+			}
+		}
+	}
+}
+
+func checkCombinators(f *frame) {
+	if f.o.AllOf != nil {
+		for i := range *f.o.AllOf {
+			f.errs = append(f.errs, f.sub(&(*f.o.AllOf)[i], f.value, nil, "allOf", strconv.Itoa(i))...)
+		}
+	}
+	if f.o.AnyOf != nil {
+		var causes []Error
+		for i := range *f.o.AnyOf {
+			errs := f.sub(&(*f.o.AnyOf)[i], f.value, nil, "anyOf", strconv.Itoa(i))
+			if len(errs) == 0 {
+				causes = nil
+				break
+			}
+			causes = append(causes, errs...)
+		}
+		if causes != nil {
+			f.fail("anyOf", causes, "value does not match any of the anyOf schemas")
+		}
+	}
+	if f.o.OneOf != nil {
+		var causes []Error
+		var matches []string
+		for i := range *f.o.OneOf {
+			errs := f.sub(&(*f.o.OneOf)[i], f.value, nil, "oneOf", strconv.Itoa(i))
+			if len(errs) == 0 {
+				matches = append(matches, strconv.Itoa(i))
+			}
+			causes = append(causes, errs...)
+		}
+		switch {
+		case len(matches) == 0:
+			f.fail("oneOf", causes, "value does not match any of the oneOf schemas")
+		case len(matches) > 1:
+			f.fail("oneOf", nil, "value matches the oneOf schemas %s, want exactly one", strings.Join(matches, ", "))
+		}
+	}
+}
+
+func checkConditional(f *frame) {
+	if f.o.Not != nil && len(f.sub(f.o.Not, f.value, nil, "not")) == 0 {
+		f.fail("not", nil, "value must not match the not schema")
+	}
+	if f.o.If == nil {
+		return
+	}
+	if len(f.sub(f.o.If, f.value, nil, "if")) == 0 {
+		if f.o.Then != nil {
+			if causes := f.sub(f.o.Then, f.value, nil, "then"); len(causes) > 0 {
+				f.fail("then", causes, "value matches the if schema but not the then schema")
+			}
+		}
+	} else if f.o.Else != nil {
+		if causes := f.sub(f.o.Else, f.value, nil, "else"); len(causes) > 0 {
+			f.fail("else", causes, "value matches neither the if schema nor the else schema")
+		}
+	}
+}
+
+// pattern compiles and caches a regular expression.
+func (v *Validator) pattern(pattern string) (*regexp.Regexp, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+func hasType(value interface{}, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := toRat(value)
+		return ok && n.IsInt()
+	case "number":
+		_, ok := toRat(value)
+		return ok
+	default:
+		return typeOf(value) == t
+	}
+}
+
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if _, ok := toRat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("unsupported %T", value)
+}
+
+// toRat returns the exact value of a JSON number.
+func toRat(value interface{}) (*big.Rat, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(n.String())
+	case float64:
+		return floatRat(n), true
+	case float32:
+		return floatRat(float64(n)), true
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	}
+	return nil, false
+}
+
+// floatRat converts f by its shortest decimal form, so that 0.1 stays one tenth.
+func floatRat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(f)
+	}
+	return r
+}
+
+// equal reports whether two JSON values are equal, comparing numbers by value.
+func equal(a, b interface{}) bool {
+	if x, ok := toRat(a); ok {
+		y, ok := toRat(b)
+		return ok && x.Cmp(y) == 0
+	}
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case nil, bool, string:
+		return a == b
+	}
+	return false
+}
+
+// describe renders enumerated values for messages.
+func describe(values []v1_4.AlwaysTrue) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
+		}
+		parts[i] = string(data)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,225 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// resource is a schema identified by a URI, in its decoded JSON form, along with the
+// base URI in effect around it; its own "$id" still applies on top.
+type resource struct {
+	node interface{}
+	base *url.URL
+}
+
+// target is a schema a reference resolved to.
+type target struct {
+	schema *v1_4.JSONSchema
+	base   *url.URL
+}
+
+// addResource registers schema under uri, along with the schemas it identifies.
+func (v *Validator) addResource(uri *url.URL, schema *v1_4.JSONSchema) error {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	node, err := Decode(data)
+	if err != nil {
+		return err
+	}
+	v.register(uri, node, uri)
+	v.index(node, uri)
+	return nil
+}
+
+// index registers every schema below node that declares an "$id". Plain name
+// fragments such as "#user" are registered along with their fragment.
+func (v *Validator) index(node interface{}, base *url.URL) {
+	if id, ok := schemaID(node); ok {
+		uri := normalize(base.ResolveReference(id))
+		v.register(uri, node, base)
+		if hasLocation(id) {
+			base = withoutFragment(uri)
+			if uri.Fragment != "" {
+				v.register(base, node, base)
+			}
+		}
+	}
+	for _, child := range rawSubschemas(node) {
+		v.index(child, base)
+	}
+}
+
+// register adds a resource unless one already has the same URI.
+func (v *Validator) register(uri *url.URL, node interface{}, base *url.URL) {
+	if _, ok := v.resources[uri.String()]; !ok {
+		v.resources[uri.String()] = resource{node: node, base: base}
+	}
+}
+
+// resolve returns the schema ref names, resolved against base.
+func (v *Validator) resolve(base *url.URL, ref string) (target, string, error) {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return target{}, ref, err
+	}
+	uri := normalize(base.ResolveReference(parsed))
+	key := uri.String()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if t, ok := v.schemas[key]; ok {
+		return t, key, nil
+	}
+	node, nodeBase, err := v.locate(uri)
+	if err != nil {
+		return target{}, key, err
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return target{}, key, err
+	}
+	var schema v1_4.JSONSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return target{}, key, fmt.Errorf("%s is not a schema: %v", key, err)
+	}
+	t := target{schema: &schema, base: nodeBase}
+	v.schemas[key] = t
+	return t, key, nil
+}
+
+// locate finds the JSON value uri identifies, along with the base URI around it.
+func (v *Validator) locate(uri *url.URL) (interface{}, *url.URL, error) {
+	if res, ok := v.resources[uri.String()]; ok {
+		return res.node, res.base, nil
+	}
+	document := withoutFragment(uri)
+	res, ok := v.resources[document.String()]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown schema %s", document)
+	}
+	if uri.Fragment == "" {
+		return res.node, res.base, nil
+	}
+	if !strings.HasPrefix(uri.Fragment, "/") {
+		return nil, nil, fmt.Errorf("unknown schema %s", uri)
+	}
+	node, base := res.node, res.base
+	for _, token := range strings.Split(uri.Fragment[1:], "/") {
+		token = source.UnescapeToken(token)
+		if id, ok := schemaID(node); ok && hasLocation(id) {
+			base = withoutFragment(normalize(base.ResolveReference(id)))
+		}
+		switch value := node.(type) {
+		case map[string]interface{}:
+			child, ok := value[token]
+			if !ok {
+				return nil, nil, fmt.Errorf("%s does not exist", uri)
+			}
+			node = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil, nil, fmt.Errorf("%s does not exist", uri)
+			}
+			node = value[i]
+		default:
+			return nil, nil, fmt.Errorf("%s does not exist", uri)
+		}
+	}
+	return node, base, nil
+}
+
+// rawSubschemas returns the schemas directly nested in the decoded schema node.
+func rawSubschemas(node interface{}) []interface{} {
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var children []interface{}
+	for _, keyword := range []string{"additionalItems", "contains", "additionalProperties", "propertyNames", "if", "then", "else", "not"} {
+		if child, ok := object[keyword]; ok {
+			children = append(children, child)
+		}
+	}
+	if items, ok := object["items"].([]interface{}); ok {
+		children = append(children, items...)
+	} else if item, ok := object["items"]; ok {
+		children = append(children, item)
+	}
+	for _, keyword := range []string{"definitions", "properties", "patternProperties", "dependencies"} {
+		if named, ok := object[keyword].(map[string]interface{}); ok {
+			for _, child := range named {
+				if _, ok := child.([]interface{}); !ok {
+					children = append(children, child)
+				}
+			}
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if array, ok := object[keyword].([]interface{}); ok {
+			children = append(children, array...)
+		}
+	}
+	return children
+}
+
+// schemaID returns the "$id" node declares, if any. Draft-07 does not allow JSON
+// pointer fragments in identifiers, and "$id" is ignored next to "$ref".
+func schemaID(node interface{}) (*url.URL, bool) {
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if _, ok := object["$ref"]; ok {
+		return nil, false
+	}
+	s, ok := object["$id"].(string)
+	if !ok {
+		return nil, false
+	}
+	id, err := url.Parse(s)
+	if err != nil || strings.HasPrefix(id.Fragment, "/") {
+		return nil, false
+	}
+	return id, true
+}
+
+// schemaBase returns the base URI of the references within o.
+func schemaBase(base *url.URL, o *v1_4.JSONSchemaObject) *url.URL {
+	if o.Id == nil || o.Ref != nil {
+		return base
+	}
+	id, err := url.Parse(string(*o.Id))
+	if err != nil || !hasLocation(id) {
+		return base
+	}
+	return withoutFragment(normalize(base.ResolveReference(id)))
+}
+
+// hasLocation reports whether id names more than a fragment, changing the base URI.
+func hasLocation(id *url.URL) bool {
+	return id.Scheme != "" || id.Host != "" || id.Path != "" || id.Opaque != ""
+}
+
+// normalize gives URIs that only differ by an empty path or "/" the same form, as
+// "https://meta.json-schema.tools" and "https://meta.json-schema.tools/" do.
+func normalize(uri *url.URL) *url.URL {
+	u := *uri
+	if u.Host != "" && u.Path == "" {
+		u.Path = "/"
+	}
+	return &u
+}
+
+func withoutFragment(uri *url.URL) *url.URL {
+	u := *uri
+	u.Fragment, u.RawFragment = "", ""
+	return &u
+}
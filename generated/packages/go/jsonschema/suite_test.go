@@ -0,0 +1,99 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// suite is a copy of the JSON-Schema-Test-Suite, holding the required draft-07 tests
+// and the remote schemas they refer to, which are served at http://localhost:1234/.
+const suite = "testdata/JSON-Schema-Test-Suite"
+
+type suiteCase struct {
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+	Tests       []struct {
+		Description string          `json:"description"`
+		Data        json.RawMessage `json:"data"`
+		Valid       bool            `json:"valid"`
+	} `json:"tests"`
+}
+
+// remotes returns the options making the remote schemas of the suite available.
+func remotes(t *testing.T) []Option {
+	var options []Option
+	root := filepath.Join(suite, "remotes")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var schema v1_4.JSONSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		options = append(options, WithSchema("http://localhost:1234/"+filepath.ToSlash(rel), &schema))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return options
+}
+
+func TestSuite(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(suite, "tests", "draft7", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no test files: %v", err)
+	}
+	options := remotes(t)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var cases []suiteCase
+		if err := json.Unmarshal(data, &cases); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			for _, c := range cases {
+				var schema v1_4.JSONSchema
+				if err := json.Unmarshal(c.Schema, &schema); err != nil {
+					t.Errorf("%s: decoding the schema: %v", c.Description, err)
+					continue
+				}
+				v, err := New(&schema, options...)
+				if err != nil {
+					t.Errorf("%s: %v", c.Description, err)
+					continue
+				}
+				for _, test := range c.Tests {
+					key := name + "/" + c.Description + "/" + test.Description
+					errs, err := v.ValidateJSON(test.Data)
+					if err != nil {
+						t.Errorf("%s: %v", key, err)
+						continue
+					}
+					if valid := len(errs) == 0; valid != test.Valid {
+						t.Errorf("%s: valid = %v, want %v (%v)", key, valid, test.Valid, errs)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package lint reports semantic problems of 1.4 OpenRPC documents that the meta-schema
+// cannot express, such as duplicate method names.
+//
+// A Linter runs a set of Rules, each with a default Severity that can be overridden or
+// turned off. Findings are suppressed by an "x-lint-ignore" extension on the offending
+// object or any object around it that allows specification extensions: true ignores
+// every rule, while a rule name or a list of them ignores only those rules.
+//
+// Error objects, example pairing objects and the components object allow no
+// extensions, so findings about them and the components within them are suppressed
+// by an object around them instead, with an x-lint-ignore object mapping JSON pointers
+// relative to that object to true, a rule name or a list of them. For example, an
+// x-lint-ignore of {"/components/errors/Reserved": "reserved-error-code"} on the
+// document suppresses that rule for the Reserved error and the values within it.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/resolve"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// IgnoreExtension is the specification extension that suppresses findings.
+const IgnoreExtension = "x-lint-ignore"
+
+// Severity grades findings.
+type Severity int
+
+const (
+	// SeverityOff turns a rule off.
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Finding is a problem a rule found in a document.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	// Path is the JSON pointer of the offending value within the document.
+	Path    string
+	Message string
+}
+
+func (f Finding) String() string {
+	path := f.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", path, f.Severity, f.Message, f.Rule)
+}
+
+// Rule is a check run against documents.
+type Rule struct {
+	// Name identifies the rule in findings, severity overrides and x-lint-ignore.
+	Name        string
+	Description string
+	// Severity is the severity of the rule's findings unless overridden.
+	Severity Severity
+	Check    func(c *Context)
+}
+
+// Context is what a rule checks, along with where it reports its findings.
+type Context struct {
+	Doc *v1_4.OpenrpcDocument
+	// Resolver resolves the references of Doc.
+	Resolver *resolve.Resolver
+	report   func(path, message string)
+}
+
+// Report records a finding at the JSON pointer path.
+func (c *Context) Report(path, format string, args ...interface{}) {
+	c.report(path, fmt.Sprintf(format, args...))
+}
+
+// Linter runs a set of rules.
+type Linter struct {
+	Rules []Rule
+	// Severities overrides the severity of rules by name.
+	Severities map[string]Severity
+}
+
+// New returns a Linter running the built-in rules.
+func New() *Linter {
+	return &Linter{Rules: Rules()}
+}
+
+// Lint runs every rule that is not turned off against doc, returning the findings
+// that are not suppressed, rule by rule.
+func (l *Linter) Lint(doc *v1_4.OpenrpcDocument) ([]Finding, error) {
+	return l.LintWith(doc, resolve.New(doc))
+}
+
+// LintWith is Lint with a Resolver configured for doc, such as one able to load the
+// documents it refers to.
+func (l *Linter) LintWith(doc *v1_4.OpenrpcDocument, resolver *resolve.Resolver) ([]Finding, error) {
+	raw, err := rawDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, rule := range l.Rules {
+		severity := rule.Severity
+		if s, ok := l.Severities[rule.Name]; ok {
+			severity = s
+		}
+		if severity == SeverityOff || rule.Check == nil {
+			continue
+		}
+		rule.Check(&Context{Doc: doc, Resolver: resolver, report: func(path, message string) {
+			if !ignored(raw, path, rule.Name) {
+				findings = append(findings, Finding{Rule: rule.Name, Severity: severity, Path: path, Message: message})
+			}
+		}})
+	}
+	return findings, nil
+}
+
+func rawDocument(doc *v1_4.OpenrpcDocument) (interface{}, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// ignored reports whether an object at or around path suppresses the rule.
+func ignored(raw interface{}, path, rule string) bool {
+	node := raw
+	tokens := []string{}
+	if path != "" {
+		tokens = strings.Split(path[1:], "/")
+	}
+	for i := 0; ; i++ {
+		if object, ok := node.(map[string]interface{}); ok && ignoresAt(object[IgnoreExtension], tokens[i:], rule) {
+			return true
+		}
+		if i == len(tokens) {
+			return false
+		}
+		token := source.UnescapeToken(tokens[i])
+		switch value := node.(type) {
+		case map[string]interface{}:
+			node = value[token]
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return false
+			}
+			node = value[index]
+		default:
+			return false
+		}
+	}
+}
+
+// ignoresAt reports whether an x-lint-ignore value suppresses the rule for the value
+// the JSON pointer tokens lead to from the object holding it.
+func ignoresAt(value interface{}, tokens []string, rule string) bool {
+	pointers, ok := value.(map[string]interface{})
+	if !ok {
+		return ignores(value, rule)
+	}
+	path := ""
+	if len(tokens) > 0 {
+		path = "/" + strings.Join(tokens, "/")
+	}
+	for pointer, value := range pointers {
+		if (path == pointer || strings.HasPrefix(path, pointer+"/")) && ignores(value, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// ignores reports whether an x-lint-ignore value names the rule.
+func ignores(value interface{}, rule string) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == rule
+	case []interface{}:
+		for _, name := range v {
+			if name == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
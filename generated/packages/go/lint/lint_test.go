@@ -0,0 +1,160 @@
+package lint
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// lint runs the built-in rules against the document data holds, returning the
+// findings as strings.
+func lint(t *testing.T, l *Linter, data string) []string {
+	t.Helper()
+	var doc v1_4.OpenrpcDocument
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	findings, err := l.Lint(&doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+	return got
+}
+
+const lintDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Lint", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "a",
+			"paramStructure": "by-position",
+			"params": [
+				{"name": "x", "schema": {}},
+				{"name": "x", "required": true, "schema": {}},
+				{"$ref": "#/components/contentDescriptors/P"}
+			],
+			"errors": [{"code": -32001, "message": "m"}, {"$ref": "#/components/errors/E"}],
+			"tags": [{"name": "t1"}, {"$ref": "#/components/tags/missing"}]
+		},
+		{"name": "a", "params": [], "x-lint-ignore": "duplicate-method-name"},
+		{"name": "a", "params": []}
+	],
+	"components": {
+		"schemas": {
+			"Used": {"type": "string"},
+			"Unused": {"$ref": "#/components/schemas/Chain"},
+			"Chain": {}
+		},
+		"contentDescriptors": {"P": {"name": "p", "required": true, "schema": {"$ref": "#/components/schemas/Used"}}},
+		"errors": {"E": {"code": -32000, "message": "x"}},
+		"tags": {"t2": {"name": "t2"}}
+	}
+}`
+
+func TestLint(t *testing.T) {
+	got := lint(t, New(), lintDoc)
+	want := []string{
+		`/methods/2: error: method name "a" is already used by /methods/0 [duplicate-method-name]`,
+		`/methods/0/params/1: error: param name "x" is already used by /methods/0/params/0 [duplicate-param-name]`,
+		`/methods/0/params/1: error: required param "x" follows the optional param "x" [required-param-after-optional]`,
+		`/methods/0/params/2: error: required param "p" follows the optional param "x" [required-param-after-optional]`,
+		`/methods/0/errors/0/code: warning: error code -32001 is in the range reserved for pre-defined errors [reserved-error-code]`,
+		`/components/errors/E/code: warning: error code -32000 is in the range reserved for pre-defined errors [reserved-error-code]`,
+		`/components/schemas/Chain: warning: component "Chain" is never referenced [unused-component]`,
+		`/components/schemas/Unused: warning: component "Unused" is never referenced [unused-component]`,
+		`/components/tags/t2: warning: component "t2" is never referenced [unused-component]`,
+		`/methods/0/tags/0: warning: tag "t1" is not defined in the components [undefined-tag]`,
+		`/methods/0/tags/1: warning: tag "#/components/tags/missing" is not defined [undefined-tag]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestLintSeverities(t *testing.T) {
+	l := New()
+	l.Severities = map[string]Severity{
+		"duplicate-method-name":         SeverityOff,
+		"duplicate-param-name":          SeverityOff,
+		"required-param-after-optional": SeverityOff,
+		"reserved-error-code":           SeverityOff,
+		"unused-component":              SeverityOff,
+		"undefined-tag":                 SeverityInfo,
+	}
+	got := lint(t, l, lintDoc)
+	want := []string{
+		`/methods/0/tags/0: info: tag "t1" is not defined in the components [undefined-tag]`,
+		`/methods/0/tags/1: info: tag "#/components/tags/missing" is not defined [undefined-tag]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestUnusedComponentDecodesReferences(t *testing.T) {
+	got := lint(t, New(), `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Refs", "version": "1.0.0"},
+		"methods": [
+			{"name": "a", "params": [], "result": {"name": "r", "schema": {"anyOf": [
+				{"$ref": "#/components/schemas/a~1b"},
+				{"$ref": "#/components/schemas/c~0d"},
+				{"$ref": "#/components/schemas/with%20space"}
+			]}}}
+		],
+		"components": {"schemas": {"a/b": {}, "c~d": {}, "with space": {}, "unused": {}}}
+	}`)
+	want := []string{`/components/schemas/unused: warning: component "unused" is never referenced [unused-component]`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestUndefinedTagWithoutComponents(t *testing.T) {
+	got := lint(t, New(), `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Tags", "version": "1.0.0"},
+		"methods": [{"name": "a", "params": [], "tags": [{"name": "inline"}, {"$ref": "#/components/tags/missing"}]}]
+	}`)
+	want := []string{
+		`/methods/0/tags/0: warning: tag "inline" is not defined in the components [undefined-tag]`,
+		`/methods/0/tags/1: warning: tag "#/components/tags/missing" is not defined [undefined-tag]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestIgnore(t *testing.T) {
+	got := lint(t, New(), `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Ignore", "version": "1.0.0"},
+		"x-lint-ignore": {
+			"/components/errors/Reserved": "reserved-error-code",
+			"/components/schemas": ["unused-component"],
+			"/methods/1": true
+		},
+		"methods": [
+			{"name": "a", "params": [], "errors": [{"code": -32000, "message": "m"}], "x-lint-ignore": ["reserved-error-code"]},
+			{"name": "a", "params": []}
+		],
+		"components": {
+			"schemas": {"Unused": {}},
+			"errors": {"Reserved": {"code": -32001, "message": "r"}, "Other": {"code": -32002, "message": "o"}}
+		}
+	}`)
+	want := []string{
+		`/components/errors/Other/code: warning: error code -32002 is in the range reserved for pre-defined errors [reserved-error-code]`,
+		`/components/errors/Other: warning: component "Other" is never referenced [unused-component]`,
+		`/components/errors/Reserved: warning: component "Reserved" is never referenced [unused-component]`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findings:\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,298 @@
+package lint
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/resolve"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Rules returns the built-in rules.
+func Rules() []Rule {
+	return []Rule{
+		{
+			Name:        "duplicate-method-name",
+			Description: "Method names must be unique, as they are the method of JSON-RPC requests.",
+			Severity:    SeverityError,
+			Check:       checkDuplicateMethodNames,
+		},
+		{
+			Name:        "duplicate-param-name",
+			Description: "Param names must be unique within a method.",
+			Severity:    SeverityError,
+			Check:       checkDuplicateParamNames,
+		},
+		{
+			Name:        "required-param-after-optional",
+			Description: "By-position params must not place a required param after an optional one, as the optional one could not be left out.",
+			Severity:    SeverityError,
+			Check:       checkRequiredParamOrder,
+		},
+		{
+			Name:        "reserved-error-code",
+			Description: "Error codes from -32768 to -32000 are reserved for pre-defined JSON-RPC errors.",
+			Severity:    SeverityWarning,
+			Check:       checkReservedErrorCodes,
+		},
+		{
+			Name:        "unused-component",
+			Description: "Components no method refers to, directly or through other components, have no effect.",
+			Severity:    SeverityWarning,
+			Check:       checkUnusedComponents,
+		},
+		{
+			Name:        "undefined-tag",
+			Description: "Tags methods refer to or declare inline must be defined in the components.",
+			Severity:    SeverityWarning,
+			Check:       checkUndefinedTags,
+		},
+	}
+}
+
+// method is a method of the document along with its JSON pointer. Inline is false for
+// methods resolved from a reference, whose contents have no path in the document.
+type method struct {
+	path   string
+	inline bool
+	*v1_4.MethodObject
+}
+
+// methods returns the methods of the document, skipping references that do not resolve.
+func (c *Context) methods() []method {
+	if c.Doc.Methods == nil {
+		return nil
+	}
+	var methods []method
+	for i, m := range *c.Doc.Methods {
+		value, err := resolve.Resolve(c.Resolver, m)
+		if err != nil || value == nil {
+			continue
+		}
+		methods = append(methods, method{path: "/methods/" + strconv.Itoa(i), inline: !m.IsRef(), MethodObject: value})
+	}
+	return methods
+}
+
+// param is a param of a method along with its JSON pointer, which is the method's
+// own for methods that are not inline.
+type param struct {
+	path string
+	*v1_4.ContentDescriptorObject
+}
+
+// params returns the params of m, skipping references that do not resolve.
+func (c *Context) params(m method) []param {
+	if m.Params == nil {
+		return nil
+	}
+	var params []param
+	for j, p := range *m.Params {
+		value, err := resolve.Resolve(c.Resolver, p)
+		if err != nil || value == nil {
+			continue
+		}
+		path := m.path
+		if m.inline {
+			path += "/params/" + strconv.Itoa(j)
+		}
+		params = append(params, param{path: path, ContentDescriptorObject: value})
+	}
+	return params
+}
+
+func checkDuplicateMethodNames(c *Context) {
+	first := map[string]string{}
+	for _, m := range c.methods() {
+		name := m.GetName()
+		if path, ok := first[name]; ok {
+			c.Report(m.path, "method name %q is already used by %s", name, path)
+			continue
+		}
+		first[name] = m.path
+	}
+}
+
+func checkDuplicateParamNames(c *Context) {
+	for _, m := range c.methods() {
+		first := map[string]string{}
+		for _, p := range c.params(m) {
+			name := p.GetName()
+			if path, ok := first[name]; ok {
+				c.Report(p.path, "param name %q is already used by %s", name, path)
+				continue
+			}
+			first[name] = p.path
+		}
+	}
+}
+
+func checkRequiredParamOrder(c *Context) {
+	for _, m := range c.methods() {
+		if m.GetParamStructure() != string(v1_4.MethodObjectParamStructureEnum0) {
+			continue
+		}
+		optional := ""
+		for _, p := range c.params(m) {
+			switch {
+			case !p.IsRequired() && optional == "":
+				optional = p.GetName()
+			case p.IsRequired() && optional != "":
+				c.Report(p.path, "required param %q follows the optional param %q", p.GetName(), optional)
+			}
+		}
+	}
+}
+
+func checkReservedErrorCodes(c *Context) {
+	check := func(path string, e *v1_4.ErrorObject) {
+		if e.Code != nil && *e.Code >= -32768 && *e.Code <= -32000 {
+			c.Report(path+"/code", "error code %d is in the range reserved for pre-defined errors", *e.Code)
+		}
+	}
+	for _, m := range c.methods() {
+		if !m.inline || m.Errors == nil {
+			continue
+		}
+		for j, e := range *m.Errors {
+			if value := e.Value(); value != nil {
+				check(m.path+"/errors/"+strconv.Itoa(j), value)
+			}
+		}
+	}
+	if c.Doc.Components != nil && c.Doc.Components.Errors != nil {
+		errs := *c.Doc.Components.Errors
+		for _, name := range sorted.Keys(errs) {
+			e := errs[name]
+			check("/components/errors/"+source.EscapeToken(name), &e)
+		}
+	}
+}
+
+func checkUnusedComponents(c *Context) {
+	components := c.Doc.Components
+	if components == nil {
+		return
+	}
+	// References lead from the component they are in, or from the rest of the
+	// document for roots, to a component. A component is used when a root reaches it.
+	edges := map[string][]string{}
+	refs, _ := c.Resolver.All()
+	for _, ref := range refs {
+		to, ok := componentRef(ref.Ref)
+		if !ok {
+			continue
+		}
+		from := componentPath(ref.Path)
+		edges[from] = append(edges[from], to)
+	}
+	used := map[string]bool{}
+	queue := append([]string(nil), edges[""]...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if !used[next] {
+			used[next] = true
+			queue = append(queue, edges[next]...)
+		}
+	}
+	// Inline tags name the tag components they stand for.
+	for _, m := range c.methods() {
+		if m.Tags == nil {
+			continue
+		}
+		for _, tag := range *m.Tags {
+			if !tag.IsRef() && tag.Value() != nil {
+				used["/components/tags/"+source.EscapeToken(deref.String(tag.Value().Name))] = true
+			}
+		}
+	}
+	for _, kind := range []string{"schemas", "links", "errors", "examples", "examplePairings", "contentDescriptors", "tags"} {
+		for _, name := range componentNames(components, kind) {
+			if path := "/components/" + kind + "/" + source.EscapeToken(name); !used[path] {
+				c.Report(path, "component %q is never referenced", name)
+			}
+		}
+	}
+}
+
+func checkUndefinedTags(c *Context) {
+	defined := map[string]bool{}
+	if c.Doc.Components != nil {
+		for _, name := range componentNames(c.Doc.Components, "tags") {
+			defined[name] = true
+		}
+	}
+	for _, m := range c.methods() {
+		if !m.inline || m.Tags == nil {
+			continue
+		}
+		for j, tag := range *m.Tags {
+			path := m.path + "/tags/" + strconv.Itoa(j)
+			if tag.IsRef() {
+				if _, err := resolve.Resolve(c.Resolver, tag); errors.Is(err, resolve.ErrDangling) {
+					c.Report(path, "tag %q is not defined", deref.String(tag.Ref().Ref))
+				}
+				continue
+			}
+			if name := deref.String(tag.Value().Name); !defined[name] {
+				c.Report(path, "tag %q is not defined in the components", name)
+			}
+		}
+	}
+}
+
+// componentPath returns the JSON pointer of the component holding the value at path,
+// or an empty string when path is outside the components.
+func componentPath(path string) string {
+	tokens := strings.SplitN(path, "/", 5)
+	if len(tokens) < 4 || tokens[0] != "" || tokens[1] != "components" {
+		return ""
+	}
+	return strings.Join(tokens[:4], "/")
+}
+
+// componentRef returns the JSON pointer of the component a reference within the
+// document names, in the form componentPath returns, undoing the percent-encoding of
+// the fragment and the escapes of the name.
+func componentRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+	uri, err := url.Parse(ref)
+	if err != nil {
+		return "", false
+	}
+	tokens := strings.SplitN(uri.Fragment, "/", 4)
+	if len(tokens) < 4 || tokens[0] != "" || tokens[1] != "components" {
+		return "", false
+	}
+	name := strings.SplitN(tokens[3], "/", 2)[0]
+	return "/components/" + tokens[2] + "/" + source.EscapeToken(source.UnescapeToken(name)), true
+}
+
+// componentNames returns the sorted names of the components of the given kind.
+func componentNames(c *v1_4.Components, kind string) []string {
+	switch {
+	case kind == "schemas" && c.Schemas != nil:
+		return sorted.Keys(*c.Schemas)
+	case kind == "links" && c.Links != nil:
+		return sorted.Keys(*c.Links)
+	case kind == "errors" && c.Errors != nil:
+		return sorted.Keys(*c.Errors)
+	case kind == "examples" && c.Examples != nil:
+		return sorted.Keys(*c.Examples)
+	case kind == "examplePairings" && c.ExamplePairings != nil:
+		return sorted.Keys(*c.ExamplePairings)
+	case kind == "contentDescriptors" && c.ContentDescriptors != nil:
+		return sorted.Keys(*c.ContentDescriptors)
+	case kind == "tags" && c.Tags != nil:
+		return sorted.Keys(*c.Tags)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package loader decodes OpenRPC documents of any supported specification version,
+// dispatching on the document's "openrpc" field.
+package loader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/openrpc"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_3"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// ErrUnsupportedVersion is returned when a document declares a specification version
+// that none of the generated packages describe.
+var ErrUnsupportedVersion = errors.New("unsupported OpenRPC version")
+
+// Result is a decoded OpenRPC document tagged with its specification version.
+// Exactly one of V1_3 and V1_4 is set, matching Version.Package().
+type Result struct {
+	Version v1_4.OpenrpcVersion
+	V1_3    *v1_3.OpenrpcDocument
+	V1_4    *v1_4.OpenrpcDocument
+	// Source holds the position of every value of the document in the text it was
+	// decoded from. It is only set by LoadSource.
+	Source *source.Map
+}
+
+// Package returns the name of the package the document was decoded with, "v1_3" or "v1_4".
+func (r *Result) Package() string {
+	return r.Version.Package()
+}
+
+// Document returns a version-independent view of the decoded document, or nil when
+// r holds none.
+func (r *Result) Document() openrpc.Document {
+	switch {
+	case r.V1_3 != nil:
+		return r.V1_3
+	case r.V1_4 != nil:
+		return r.V1_4
+	}
+	return nil
+}
+
+// Load decodes an OpenRPC document into the package matching its "openrpc" version.
+func Load(data []byte) (*Result, error) {
+	var sniff struct {
+		Openrpc *string `json:"openrpc"`
+	}
+	if err := json.Unmarshal(data, &sniff); err != nil {
+		return nil, fmt.Errorf("decoding OpenRPC document: %w", err)
+	}
+	if sniff.Openrpc == nil {
+		return nil, errors.New("decoding OpenRPC document: missing openrpc field")
+	}
+	version, err := v1_4.ParseOpenrpcVersion(*sniff.Openrpc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedVersion, err)
+	}
+	result := &Result{Version: version}
+	switch version.Package() {
+	case "v1_3":
+		result.V1_3 = &v1_3.OpenrpcDocument{}
+		err = json.Unmarshal(data, result.V1_3)
+	case "v1_4":
+		result.V1_4 = &v1_4.OpenrpcDocument{}
+		err = json.Unmarshal(data, result.V1_4)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedVersion, *sniff.Openrpc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding OpenRPC %s document: %w", version, err)
+	}
+	return result, nil
+}
+
+// LoadSource is Load recording the position of every object and field of the
+// document in Result.Source. Errors decoding the document hold a *source.Error,
+// as found by errors.As, locating the offending value.
+func LoadSource(data []byte) (*Result, error) {
+	m, err := source.Index(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding OpenRPC document: %w", err)
+	}
+	result, err := Load(data)
+	if err != nil {
+		return nil, locate(data, m, err)
+	}
+	result.Source = m
+	return result, nil
+}
+
+// locate wraps err, an error decoding data, with the position of the value that
+// caused it. The decoders of the generated types report offsets relative to the
+// nested values they decode, so the value is found by decoding the members of the
+// document one by one, down to the deepest one that fails.
+func locate(data []byte, m *source.Map, err error) error {
+	var syntax *json.SyntaxError
+	switch {
+	case errors.As(err, &syntax):
+		return &source.Error{Position: m.Position(int(syntax.Offset)), Err: err}
+	case errors.Is(err, ErrUnsupportedVersion):
+		return m.Locate("/openrpc", err)
+	}
+	var sniff struct {
+		Openrpc string `json:"openrpc"`
+	}
+	var doc interface{} = &sniff
+	if json.Unmarshal(data, &sniff) == nil {
+		if version, err := v1_4.ParseOpenrpcVersion(sniff.Openrpc); err == nil {
+			switch version.Package() {
+			case "v1_3":
+				doc = &v1_3.OpenrpcDocument{}
+			case "v1_4":
+				doc = &v1_4.OpenrpcDocument{}
+			}
+		}
+	}
+	return m.Locate(failing(data, reflect.TypeOf(doc), ""), err)
+}
+
+// LoadReader reads r to completion and decodes it with Load.
+func LoadReader(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenRPC document: %w", err)
+	}
+	return Load(data)
+}
+
+// LoadSourceReader reads r to completion and decodes it with LoadSource.
+func LoadSourceReader(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenRPC document: %w", err)
+	}
+	return LoadSource(data)
+}
@@ -0,0 +1,152 @@
+package loader
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+)
+
+const (
+	doc13 = `{"openrpc": "1.2.6", "info": {"title": "Old", "version": "1.0.0"}, "methods": []}`
+	doc14 = `{"openrpc": "1.4.0", "info": {"title": "New", "version": "2.0.0"}, "methods": []}`
+)
+
+func TestLoadDispatchesOnVersion(t *testing.T) {
+	r, err := Load([]byte(doc13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Package() != "v1_3" || r.V1_3 == nil || r.V1_4 != nil || *r.V1_3.Info.Title != "Old" {
+		t.Errorf("1.2.6 document: %+v", r)
+	}
+	r, err = Load([]byte(doc14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Package() != "v1_4" || r.V1_4 == nil || r.V1_3 != nil || *r.V1_4.Info.Title != "New" {
+		t.Errorf("1.4.0 document: %+v", r)
+	}
+	if r.Version.Minor != 4 {
+		t.Errorf("Version = %v", r.Version)
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	for _, version := range []string{"2.0.0", "1.2.7", "1.4", "latest"} {
+		_, err := Load([]byte(`{"openrpc": "` + version + `", "info": {}, "methods": []}`))
+		if !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("%s: got %v, want ErrUnsupportedVersion", version, err)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for _, data := range []string{
+		`not json`,
+		`{"info": {}, "methods": []}`,
+		`{"openrpc": "1.4.0", "info": {"title": 1}, "methods": []}`,
+	} {
+		if r, err := Load([]byte(data)); err == nil {
+			t.Errorf("%s: got %+v, want an error", data, r)
+		} else if errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("%s: got %v", data, err)
+		}
+	}
+}
+
+func TestLoadReader(t *testing.T) {
+	r, err := LoadReader(strings.NewReader(doc14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.V1_4 == nil {
+		t.Errorf("got %+v", r)
+	}
+}
+
+func TestLoadSource(t *testing.T) {
+	r, err := LoadSource([]byte(doc14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := r.Source.Lookup("/info/title"); r.V1_4 == nil || !ok || p.String() != "1:31" {
+		t.Errorf("got %+v, /info/title at %v", r, p)
+	}
+	if r, _ := Load([]byte(doc14)); r.Source != nil {
+		t.Error("Load set Source")
+	}
+}
+
+func TestLoadSourceLocatesErrors(t *testing.T) {
+	for _, c := range []struct {
+		data, pointer, position string
+	}{
+		{`{"openrpc": "1.4.0", "info": {"title": 1}, "methods": []}`, "/info/title", "1:31"},
+		{`{"openrpc": "1.2.6", "info": {"version": true}, "methods": []}`, "/info/version", "1:31"},
+		{`{"openrpc": 1, "methods": []}`, "/openrpc", "1:2"},
+		{`{"openrpc": "9.0.0", "methods": []}`, "/openrpc", "1:2"},
+		{`{"openrpc": "1.4.0", "methods": [1]}`, "/methods/0", "1:34"},
+		{`{"openrpc": "1.4.0", "methods": [{"$ref": 1}]}`, "/methods/0/$ref", "1:35"},
+		{
+			"{\n  \"openrpc\": \"1.4.0\",\n  \"methods\": [\n    {\"name\": \"a\", \"params\": [{\"name\": \"p\", \"schema\": {\"minLength\": \"1\"}}]}\n  ]\n}",
+			"/methods/0/params/0/schema/minLength", "4:55",
+		},
+		{
+			`{"openrpc": "1.4.0", "methods": [], "components": {"schemas": {"a/b": {"items": [{"type": 5}]}}}}`,
+			"/components/schemas/a~1b/items/0/type", "1:83",
+		},
+		// Values that fail to decode without any of their members failing are located
+		// themselves.
+		{`{"openrpc": "1.4.0", "methods": [{"name": "a", "params": [null]}]}`, "/methods/0/params/0", "1:59"},
+	} {
+		_, err := LoadSource([]byte(c.data))
+		var located *source.Error
+		if !errors.As(err, &located) {
+			t.Errorf("%s: got %v, want a *source.Error", c.data, err)
+			continue
+		}
+		if located.Pointer != c.pointer || located.Position.String() != c.position {
+			t.Errorf("%s: located at %q (%v), want %q (%s): %v", c.data, located.Pointer, located.Position, c.pointer, c.position, err)
+		}
+	}
+}
+
+func TestLoadSourceSyntaxError(t *testing.T) {
+	_, err := LoadSource([]byte("{\n  \"openrpc\" \"1.4.0\"}"))
+	var located *source.Error
+	if !errors.As(err, &located) || located.Position.String() != "2:13" {
+		t.Errorf("got %v, want an error at 2:13", err)
+	}
+}
+
+func TestLoadSourceReader(t *testing.T) {
+	r, err := LoadSourceReader(strings.NewReader(doc14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.V1_4 == nil || r.Source == nil {
+		t.Errorf("got %+v", r)
+	}
+	_, err = LoadSourceReader(strings.NewReader(`{"openrpc": "1.4.0", "info": {"title": 1}}`))
+	var located *source.Error
+	if !errors.As(err, &located) || located.Pointer != "/info/title" {
+		t.Errorf("got %v, want an error at /info/title", err)
+	}
+}
+
+func TestResultDocument(t *testing.T) {
+	for _, data := range []string{doc13, doc14} {
+		r, err := Load([]byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if doc := r.Document(); doc == nil || doc.GetOpenrpc() != r.Version.String() {
+			t.Errorf("Document() = %v", doc)
+		}
+	}
+	if doc := (&Result{}).Document(); doc != nil {
+		t.Errorf("empty Result: Document() = %#v, want nil", doc)
+	}
+}
@@ -0,0 +1,222 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+)
+
+// failing returns the JSON pointer of the value keeping raw, the value at pointer,
+// from decoding into a t: the deepest value along the members t declares that fails
+// to decode while all of its own members decode. Raw is assumed not to decode.
+func failing(raw json.RawMessage, t reflect.Type, pointer string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value, ref, ok := orRef(t); ok {
+		if members, _ := members(raw); hasMember(members, "$ref") {
+			return descend(raw, ref, pointer)
+		}
+		return descend(raw, value, pointer)
+	}
+	if alternatives := alternatives(t); alternatives != nil {
+		kind := jsonKind(raw)
+		for _, alternative := range alternatives {
+			if accepts(alternative, kind) {
+				return descend(raw, alternative, pointer)
+			}
+		}
+		return pointer
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		members, _ := members(raw)
+		for _, member := range members {
+			if field, ok := fieldByName(t, member.name); ok && !decodes(member.value, field.Type) {
+				return failing(member.value, field.Type, pointer+"/"+source.EscapeToken(member.name))
+			}
+		}
+	case reflect.Map:
+		members, _ := members(raw)
+		for _, member := range members {
+			if !decodes(member.value, t.Elem()) {
+				return failing(member.value, t.Elem(), pointer+"/"+source.EscapeToken(member.name))
+			}
+		}
+	case reflect.Slice:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) == nil {
+			for i, item := range items {
+				if !decodes(item, t.Elem()) {
+					return failing(item, t.Elem(), pointer+"/"+strconv.Itoa(i))
+				}
+			}
+		}
+	}
+	return pointer
+}
+
+// descend returns the pointer failing finds for raw as a t, or pointer itself when
+// raw decodes into a t.
+func descend(raw json.RawMessage, t reflect.Type, pointer string) string {
+	if decodes(raw, t) {
+		return pointer
+	}
+	return failing(raw, t, pointer)
+}
+
+func decodes(raw json.RawMessage, t reflect.Type) bool {
+	return json.Unmarshal(raw, reflect.New(t).Interface()) == nil
+}
+
+// orRef returns the types an OrRef of the generated packages holds, the value and the
+// Reference Object, or false for other types.
+func orRef(t reflect.Type) (value, ref reflect.Type, ok bool) {
+	valueMethod, hasValue := t.MethodByName("Value")
+	refMethod, hasRef := t.MethodByName("Ref")
+	_, hasIsRef := t.MethodByName("IsRef")
+	if t.Kind() != reflect.Struct || !hasValue || !hasRef || !hasIsRef {
+		return nil, nil, false
+	}
+	return valueMethod.Type.Out(0), refMethod.Type.Out(0), true
+}
+
+// alternatives returns the types a union of the generated packages holds one of,
+// which are its fields, as none of them has a JSON tag. It returns nil for other types.
+func alternatives(t reflect.Type) []reflect.Type {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var types []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, tagged := field.Tag.Lookup("json"); tagged {
+			return nil
+		}
+		types = append(types, field.Type)
+	}
+	return types
+}
+
+// accepts reports whether a JSON value of the given kind, as jsonKind returns it,
+// may decode into a t.
+func accepts(t reflect.Type, kind byte) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if alternatives := alternatives(t); alternatives != nil {
+		for _, alternative := range alternatives {
+			if accepts(alternative, kind) {
+				return true
+			}
+		}
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return kind == '{'
+	case reflect.Slice, reflect.Array:
+		return kind == '[' || t == reflect.TypeOf(json.RawMessage(nil))
+	case reflect.String:
+		return kind == '"'
+	case reflect.Bool:
+		return kind == 't'
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return kind == '0'
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// jsonKind returns the kind of the JSON value raw holds: '{', '[', '"', 't' for
+// booleans, 'n' for null and '0' for numbers.
+func jsonKind(raw json.RawMessage) byte {
+	trimmed := bytes.TrimLeft(raw, " \t\r\n")
+	if len(trimmed) == 0 {
+		return 0
+	}
+	switch c := trimmed[0]; c {
+	case '{', '[', '"', 'n':
+		return c
+	case 't', 'f':
+		return 't'
+	}
+	return '0'
+}
+
+// fieldByName returns the field of struct type t that the object member name decodes
+// into, matching encoding/json, which prefers an exact match of the JSON name.
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	var folded reflect.StructField
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			key, _, _ = strings.Cut(tag, ",")
+			if key == "-" {
+				continue
+			}
+			if key == "" {
+				key = field.Name
+			}
+		}
+		if key == name {
+			return field, true
+		}
+		if !found && strings.EqualFold(key, name) {
+			folded, found = field, true
+		}
+	}
+	return folded, found
+}
+
+type member struct {
+	name  string
+	value json.RawMessage
+}
+
+// members returns the members of the JSON object raw holds, in document order, or
+// false when raw holds no object.
+func members(raw json.RawMessage) ([]member, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+	var members []member
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+		members = append(members, member{name: name, value: value})
+	}
+	return members, true
+}
+
+func hasMember(members []member, name string) bool {
+	for _, m := range members {
+		if m.name == name {
+			return true
+		}
+	}
+	return false
+}
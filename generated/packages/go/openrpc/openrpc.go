@@ -0,0 +1,63 @@
+// Package openrpc declares read-only views of an OpenRPC document that do not depend on
+// the specification version. The OpenrpcDocument, MethodObject, ContentDescriptorObject
+// and JSONSchema types of every generated package implement them, so tooling written
+// against these interfaces works with documents of any supported version.
+//
+// The views only expose inline objects: entries that are Reference Objects are skipped.
+package openrpc
+
+// Document is a version-independent view of an OpenRPC document.
+type Document interface {
+	// GetOpenrpc returns the specification version the document uses.
+	GetOpenrpc() string
+	// GetTitle returns the title of the application.
+	GetTitle() string
+	// GetDescription returns the description of the application.
+	GetDescription() string
+	// GetVersion returns the version of the API, not of the specification.
+	GetVersion() string
+	// GetMethods returns the methods defined inline in the document.
+	GetMethods() []Method
+}
+
+// Method is a version-independent view of a Method Object.
+type Method interface {
+	GetName() string
+	GetSummary() string
+	GetDescription() string
+	// GetParamStructure returns "by-position", "by-name" or "either", the default.
+	GetParamStructure() string
+	// GetParams returns the params defined inline, in order.
+	GetParams() []ContentDescriptor
+	// GetResult returns the result when it is defined inline, or nil.
+	GetResult() ContentDescriptor
+	// GetTags returns the names of the tags defined inline.
+	GetTags() []string
+	IsDeprecated() bool
+}
+
+// ContentDescriptor is a version-independent view of a Content Descriptor Object.
+type ContentDescriptor interface {
+	GetName() string
+	GetSummary() string
+	GetDescription() string
+	IsRequired() bool
+	IsDeprecated() bool
+	// GetSchema returns the schema of the content, or nil.
+	GetSchema() Schema
+}
+
+// Schema is a version-independent view of a JSON Schema.
+type Schema interface {
+	// Boolean returns the value of a boolean schema; ok is false for object schemas.
+	Boolean() (value bool, ok bool)
+	GetRef() string
+	GetTitle() string
+	GetDescription() string
+	// GetTypes returns the "type" keyword, normalised to a list.
+	GetTypes() []string
+	GetRequired() []string
+	GetProperties() map[string]Schema
+	// MarshalJSON returns the schema's JSON, for keywords the view does not expose.
+	MarshalJSON() ([]byte, error)
+}
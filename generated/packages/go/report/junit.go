@@ -0,0 +1,75 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/lint"
+)
+
+// The JUnit XML elements the reports use.
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// JUnit writes the findings of reports to w as a JUnit XML report, with a test suite
+// per document and a test case per finding. Errors and warnings are failures, while
+// info findings pass with their message as output. Documents without findings get
+// a single passing test case, so that they still show up in the report.
+func JUnit(w io.Writer, reports ...*Report) error {
+	suites := junitSuites{Name: "spec-types"}
+	for _, r := range reports {
+		suite := junitSuite{Name: r.URI}
+		for _, f := range r.Findings {
+			location := r.location(f)
+			c := junitCase{Name: f.Rule + " " + pointer(f.Pointer), ClassName: r.URI}
+			if f.Severity >= lint.SeverityWarning {
+				c.Failure = &junitFailure{Message: f.Message, Type: f.Severity.String(), Text: location + ": " + f.Message}
+				suite.Failures++
+			} else {
+				c.SystemOut = location + ": " + f.Message
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitCase{Name: "no findings", ClassName: r.URI})
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
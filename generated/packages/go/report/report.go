@@ -0,0 +1,91 @@
+// Package report formats lint and validation findings for CI systems, as SARIF 2.1.0
+// logs for code scanning and as JUnit XML test reports. Findings are located in the
+// files they come from by mapping their JSON pointers back to lines and columns.
+package report
+
+import (
+	"fmt"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/lint"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/validate"
+)
+
+// MetaSchemaRule prefixes the rule of findings from validation, followed by the
+// failing meta-schema keyword, as in "meta-schema/required".
+const MetaSchemaRule = "meta-schema/"
+
+// Finding is a lint finding or a validation violation.
+type Finding struct {
+	Rule     string
+	Severity lint.Severity
+	// Pointer is the JSON pointer of the offending value within the document.
+	Pointer string
+	Message string
+}
+
+// FromLint converts lint findings.
+func FromLint(findings []lint.Finding) []Finding {
+	converted := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		converted = append(converted, Finding{Rule: f.Rule, Severity: f.Severity, Pointer: f.Path, Message: f.Message})
+	}
+	return converted
+}
+
+// FromViolations converts validation violations into findings with SeverityError.
+func FromViolations(violations []validate.Violation) []Finding {
+	converted := make([]Finding, 0, len(violations))
+	for _, v := range violations {
+		converted = append(converted, Finding{Rule: MetaSchemaRule + v.Keyword, Severity: lint.SeverityError, Pointer: v.Pointer, Message: v.Message})
+	}
+	return converted
+}
+
+// Report holds the findings of one document.
+type Report struct {
+	// URI names the document's file in the output, such as a path relative to the
+	// root of the repository.
+	URI string
+	// Map locates findings in the file. Findings are reported without a line and
+	// column when it is nil.
+	Map      *source.Map
+	Findings []Finding
+}
+
+// New returns a Report for the file at uri holding data, locating findings in data.
+// The findings are reported without positions when data is not JSON.
+func New(uri string, data []byte, findings ...Finding) *Report {
+	m, err := source.Index(data)
+	if err != nil {
+		m = nil
+	}
+	return &Report{URI: uri, Map: m, Findings: findings}
+}
+
+// position returns the position of the value at pointer, or of its closest ancestor
+// the file has, reporting false when r has no Map.
+func (r *Report) position(pointer string) (source.Position, bool) {
+	if r.Map == nil {
+		return source.Position{}, false
+	}
+	p, _ := r.Map.Nearest(pointer)
+	return p, true
+}
+
+// location formats where f is, as "uri:line:column" or "uri" followed by its pointer.
+func (r *Report) location(f Finding) string {
+	if p, ok := r.position(f.Pointer); ok {
+		return fmt.Sprintf("%s:%d:%d", r.URI, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s#%s", r.URI, f.Pointer)
+}
+
+// descriptions returns the descriptions of the built-in lint rules by name.
+func descriptions() map[string]string {
+	d := map[string]string{}
+	for _, rule := range lint.Rules() {
+		d[rule.Name] = rule.Description
+	}
+	return d
+}
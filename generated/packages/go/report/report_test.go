@@ -0,0 +1,118 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/lint"
+	"github.com/zcstarr/spec-types/generated/packages/go/validate"
+)
+
+const reportDoc = "{\n  \"openrpc\": \"1.4.0\",\n  \"info\": {\"title\": \"t\"},\n  \"methods\": [\n    {\"name\": \"a\", \"params\": []}\n  ]\n}"
+
+func reports() []*Report {
+	findings := append(FromLint([]lint.Finding{
+		{Rule: "duplicate-method-name", Severity: lint.SeverityError, Path: "/methods/0", Message: "duplicate"},
+		{Rule: "unused-component", Severity: lint.SeverityInfo, Path: "/components/schemas/Gone", Message: "unused"},
+	}), FromViolations([]validate.Violation{
+		{Pointer: "/info", Keyword: "required", Message: `missing required property "version"`},
+	})...)
+	return []*Report{
+		New("openrpc.json", []byte(reportDoc), findings...),
+		New("broken.json", []byte("{"), Finding{Rule: "duplicate-method-name", Severity: lint.SeverityWarning, Message: "root"}),
+		New("clean.json", []byte("{}")),
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SARIF(&buf, reports()...); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("%v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].ColumnKind != "unicodeCodePoints" {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	var rules []string
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.ShortDescription == nil {
+			t.Errorf("rule %s has no description", rule.ID)
+		}
+		rules = append(rules, rule.ID)
+	}
+	if want := []string{"duplicate-method-name", "unused-component", "meta-schema/required"}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("rules = %q, want %q", rules, want)
+	}
+
+	var got []string
+	for _, result := range run.Results {
+		location := result.Locations[0]
+		line := result.RuleID + " " + result.Level + " " + location.PhysicalLocation.ArtifactLocation.URI + " " + location.LogicalLocations[0].FullyQualifiedName
+		if region := location.PhysicalLocation.Region; region != nil {
+			line += " " + strconv.Itoa(region.StartLine) + ":" + strconv.Itoa(region.StartColumn)
+		}
+		if rules[result.RuleIndex] != result.RuleID {
+			t.Errorf("%s: ruleIndex %d names %s", result.RuleID, result.RuleIndex, rules[result.RuleIndex])
+		}
+		got = append(got, line)
+	}
+	want := []string{
+		"duplicate-method-name error openrpc.json /methods/0 5:5",
+		// The missing component is located at its closest ancestor, the document.
+		"unused-component note openrpc.json /components/schemas/Gone 1:1",
+		"meta-schema/required error openrpc.json /info 3:3",
+		// broken.json is not JSON, so its findings have no region.
+		"duplicate-method-name warning broken.json /",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("results:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := JUnit(&buf, reports()...); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("missing XML header:\n%s", buf.String())
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &suites); err != nil {
+		t.Fatalf("%v\n%s", err, buf.String())
+	}
+	if suites.Tests != 5 || suites.Failures != 3 || len(suites.Suites) != 3 {
+		t.Fatalf("suites: %d tests, %d failures, %d suites", suites.Tests, suites.Failures, len(suites.Suites))
+	}
+	var got []string
+	for _, suite := range suites.Suites {
+		for _, c := range suite.Cases {
+			line := suite.Name + " | " + c.Name + " | "
+			if c.Failure != nil {
+				line += c.Failure.Type + " " + c.Failure.Text
+			} else {
+				line += c.SystemOut
+			}
+			got = append(got, line)
+		}
+	}
+	want := []string{
+		"openrpc.json | duplicate-method-name /methods/0 | error openrpc.json:5:5: duplicate",
+		"openrpc.json | unused-component /components/schemas/Gone | openrpc.json:1:1: unused",
+		`openrpc.json | meta-schema/required /info | error openrpc.json:3:3: missing required property "version"`,
+		"broken.json | duplicate-method-name / | warning broken.json#: root",
+		"clean.json | no findings | ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cases:\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/lint"
+)
+
+// The subset of SARIF 2.1.0 the reports use.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool       sarifTool     `json:"tool"`
+		ColumnKind string        `json:"columnKind"`
+		Results    []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string        `json:"id"`
+		ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+		LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+	sarifLogicalLocation struct {
+		// FullyQualifiedName holds the finding's JSON pointer.
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+)
+
+// SARIF writes the findings of reports to w as a SARIF 2.1.0 log with a single run.
+// Lines and columns count Unicode code points, as the run's columnKind states.
+func SARIF(w io.Writer, reports ...*Report) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "spec-types",
+			InformationURI: "https://github.com/zcstarr/spec-types",
+			Rules:          []sarifRule{},
+		}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    []sarifResult{},
+	}
+	known := descriptions()
+	indexes := map[string]int{}
+	for _, r := range reports {
+		for _, f := range r.Findings {
+			index, ok := indexes[f.Rule]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				indexes[f.Rule] = index
+				rule := sarifRule{ID: f.Rule}
+				if description, ok := known[f.Rule]; ok {
+					rule.ShortDescription = &sarifMessage{Text: description}
+				} else if strings.HasPrefix(f.Rule, MetaSchemaRule) {
+					rule.ShortDescription = &sarifMessage{Text: "Documents must conform to the OpenRPC meta-schema's " + strings.TrimPrefix(f.Rule, MetaSchemaRule) + " keyword."}
+				}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+			}
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: r.URI}},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: pointer(f.Pointer)}},
+			}
+			if p, ok := r.position(f.Pointer); ok {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: p.Line, StartColumn: p.Column}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    f.Rule,
+				RuleIndex: index,
+				Level:     sarifLevel(f.Severity),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func sarifLevel(s lint.Severity) string {
+	switch s {
+	case lint.SeverityError:
+		return "error"
+	case lint.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// pointer returns the JSON pointer p, spelling the document's root as "/".
+func pointer(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return p
+}
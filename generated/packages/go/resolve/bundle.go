@@ -0,0 +1,464 @@
+package resolve
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// BundleOptions controls which values Bundle hoists into the components.
+type BundleOptions struct {
+	// MinOccurrences is the number of identical inline copies a value needs to be
+	// hoisted. Zero means 2. Values identical to an existing component are replaced
+	// with a reference to it regardless.
+	MinOccurrences int
+}
+
+// Bundle returns a copy of doc in which identical inline schemas, errors, content
+// descriptors and example pairings are moved into the matching components and replaced
+// with references to them. Components are named after the title, name or message of
+// their value, or else after where it was first found, such as the property or content
+// descriptor holding a schema. Names already taken get a hash of the value appended, so
+// that bundling is stable. Components are only added when something is hoisted. Schemas are only
+// hoisted when their JSON is longer than the reference replacing them. Schemas declaring an
+// "$id" are left in place along with everything inside them, as moving them would
+// change how their references resolve.
+func Bundle(doc *v1_4.OpenrpcDocument, options BundleOptions) (*v1_4.OpenrpcDocument, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var bundled v1_4.OpenrpcDocument
+	if err := json.Unmarshal(data, &bundled); err != nil {
+		return nil, err
+	}
+	min := options.MinOccurrences
+	if min <= 0 {
+		min = 2
+	}
+	// Hoisting the largest value first means values nested in it are only counted
+	// once, within the component, on the next round.
+	for {
+		h, ok, err := nextHoist(&bundled, min)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return &bundled, nil
+		}
+		if err := h.apply(&bundled); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// hoist moves every inline copy of a value into a component.
+type hoist struct {
+	kind   string
+	canon  string
+	origin string
+	name   string
+	// exists is set when the component is already defined.
+	exists bool
+}
+
+func (h hoist) ref() string {
+	return "#/components/" + h.kind + "/" + source.EscapeToken(h.name)
+}
+
+func (h hoist) apply(doc *v1_4.OpenrpcDocument) error {
+	if !h.exists {
+		value, err := decode(json.RawMessage(h.canon), h.kind)
+		if err != nil {
+			return err
+		}
+		if doc.Components == nil {
+			doc.Components = &v1_4.Components{}
+		}
+		setComponent(doc.Components, h.kind, h.name, value)
+	}
+	return eachCandidate(doc, func(kind, canon, _ string, replace func(ref string)) bool {
+		if kind == h.kind && canon == h.canon {
+			replace(h.ref())
+			return false
+		}
+		return true
+	})
+}
+
+// nextHoist picks the largest value worth hoisting, if any.
+func nextHoist(doc *v1_4.OpenrpcDocument, min int) (hoist, bool, error) {
+	counts := map[[2]string]int{}
+	origins := map[[2]string]string{}
+	err := eachCandidate(doc, func(kind, canon, origin string, _ func(string)) bool {
+		key := [2]string{kind, canon}
+		if counts[key] == 0 {
+			origins[key] = origin
+		}
+		counts[key]++
+		return true
+	})
+	if err != nil {
+		return hoist{}, false, err
+	}
+	existing, err := componentsByValue(doc.Components)
+	if err != nil {
+		return hoist{}, false, err
+	}
+	keys := make([][2]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i][1]) != len(keys[j][1]) {
+			return len(keys[i][1]) > len(keys[j][1])
+		}
+		return keys[i][0]+keys[i][1] < keys[j][0]+keys[j][1]
+	})
+	for _, key := range keys {
+		h := hoist{kind: key[0], canon: key[1], origin: origins[key]}
+		h.name, h.exists = existing[key]
+		if !h.exists {
+			if counts[key] < min {
+				continue
+			}
+			name, err := componentName(doc.Components, h.kind, h.canon, h.origin)
+			if err != nil {
+				return hoist{}, false, err
+			}
+			h.name = name
+		}
+		if h.kind != "schemas" || len(h.canon) > len(`{"$ref":"`+h.ref()+`"}`) {
+			return h, true, nil
+		}
+	}
+	return hoist{}, false, nil
+}
+
+// candidateFunc receives the kind and canonical JSON of an inline value Bundle may
+// hoist, a name for it derived from where it was found, and a function replacing it
+// with a reference. It returns whether the values nested in it should be visited.
+type candidateFunc func(kind, canon, origin string, replace func(ref string)) bool
+
+// eachCandidate calls fn for every inline value of doc that can be hoisted. Component
+// entries themselves are not candidates, but the values nested in them are.
+func eachCandidate(doc *v1_4.OpenrpcDocument, fn candidateFunc) error {
+	c := candidates{fn: fn}
+	if doc.Methods != nil {
+		for _, m := range *doc.Methods {
+			if !m.IsRef() && m.Value() != nil {
+				c.method(m.Value())
+			}
+		}
+	}
+	if doc.Components == nil {
+		return c.err
+	}
+	if doc.Components.Schemas != nil {
+		schemas := *doc.Components.Schemas
+		for _, name := range sorted.Keys(schemas) {
+			schema := schemas[name]
+			c.subschemas(pascalCase(name), &schema)
+			schemas[name] = schema
+		}
+	}
+	if doc.Components.ContentDescriptors != nil {
+		descriptors := *doc.Components.ContentDescriptors
+		for _, name := range sorted.Keys(descriptors) {
+			descriptor := descriptors[name]
+			c.contentDescriptorSchema(&descriptor)
+			descriptors[name] = descriptor
+		}
+	}
+	return c.err
+}
+
+type candidates struct {
+	fn  candidateFunc
+	err error
+}
+
+func (c *candidates) visit(kind, origin string, value interface{}, replace func(ref string)) bool {
+	if c.err != nil {
+		return false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.err = err
+		return false
+	}
+	return c.fn(kind, string(data), origin, replace)
+}
+
+func (c *candidates) method(m *v1_4.MethodObject) {
+	if m.Params != nil {
+		params := *m.Params
+		for i := range params {
+			c.contentDescriptor(&params[i])
+		}
+	}
+	if m.Result != nil {
+		c.contentDescriptor(m.Result)
+	}
+	if m.Errors != nil {
+		errs := *m.Errors
+		for i := range errs {
+			if !errs[i].IsRef() && errs[i].Value() != nil {
+				c.visit("errors", "", errs[i].Value(), func(ref string) {
+					errs[i] = v1_4.NewRef[v1_4.ErrorObject](ref)
+				})
+			}
+		}
+	}
+	if m.Examples != nil {
+		examples := *m.Examples
+		for i := range examples {
+			if !examples[i].IsRef() && examples[i].Value() != nil {
+				c.visit("examplePairings", "", examples[i].Value(), func(ref string) {
+					examples[i] = v1_4.NewRef[v1_4.ExamplePairingObject](ref)
+				})
+			}
+		}
+	}
+}
+
+func (c *candidates) contentDescriptor(o *v1_4.OrRef[v1_4.ContentDescriptorObject]) {
+	if o.IsRef() || o.Value() == nil {
+		return
+	}
+	descriptor := o.Value()
+	descend := c.visit("contentDescriptors", "", descriptor, func(ref string) {
+		*o = v1_4.NewRef[v1_4.ContentDescriptorObject](ref)
+	})
+	if descend {
+		c.contentDescriptorSchema(descriptor)
+	}
+}
+
+func (c *candidates) contentDescriptorSchema(d *v1_4.ContentDescriptorObject) {
+	if d.Schema == nil {
+		return
+	}
+	origin := ""
+	if d.Name != nil {
+		origin = pascalCase(string(*d.Name))
+	}
+	schema := v1_4.JSONSchema(*d.Schema)
+	c.schema(origin, &schema)
+	*d.Schema = v1_4.ContentDescriptorObjectSchema(schema)
+}
+
+func (c *candidates) schema(origin string, s *v1_4.JSONSchema) {
+	o := s.JSONSchemaObject
+	if o == nil || o.Id != nil || o.Ref != nil {
+		return
+	}
+	descend := c.visit("schemas", origin, s, func(ref string) {
+		r := v1_4.Ref(ref)
+		*s = v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{Ref: &r}}
+	})
+	if descend {
+		c.subschemas(origin, s)
+	}
+}
+
+func (c *candidates) subschemas(origin string, s *v1_4.JSONSchema) {
+	if s.JSONSchemaObject == nil || s.JSONSchemaObject.Id != nil {
+		return
+	}
+	eachSubschema(s.JSONSchemaObject, func(tokens []string, child *v1_4.JSONSchema) {
+		c.schema(subschemaOrigin(origin, tokens), child)
+	})
+}
+
+// subschemaOrigin names a subschema after the property, definition or dependency
+// holding it, or else after its parent and the keyword holding it, as in "PetItem".
+func subschemaOrigin(parent string, tokens []string) string {
+	switch tokens[0] {
+	case "properties", "definitions", "dependencies":
+		if name := pascalCase(tokens[1]); name != "" {
+			return name
+		}
+	}
+	if parent == "" {
+		return ""
+	}
+	suffix, ok := map[string]string{
+		"items":                "Item",
+		"additionalItems":      "Item",
+		"contains":             "Item",
+		"additionalProperties": "Value",
+		"patternProperties":    "Value",
+		"propertyNames":        "Key",
+	}[tokens[0]]
+	if !ok {
+		suffix = pascalCase(tokens[0])
+	}
+	return parent + suffix
+}
+
+// componentsByValue indexes the hoistable components by kind and canonical JSON.
+func componentsByValue(c *v1_4.Components) (map[[2]string]string, error) {
+	index := map[[2]string]string{}
+	add := func(kind, name string, value interface{}) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		key := [2]string{kind, string(data)}
+		if existing, ok := index[key]; !ok || name < existing {
+			index[key] = name
+		}
+		return nil
+	}
+	for kind, names := range componentNames(c) {
+		for _, name := range names {
+			if err := add(kind, name, component(c, kind, name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return index, nil
+}
+
+// componentName returns a name for a new component holding the value canon, found
+// at a place origin names.
+func componentName(c *v1_4.Components, kind, canon, origin string) (string, error) {
+	var label struct {
+		Title   string `json:"title"`
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(canon), &label); err != nil {
+		return "", err
+	}
+	fallback := map[string]string{
+		"schemas":            "Schema",
+		"contentDescriptors": "ContentDescriptor",
+		"errors":             "Error",
+		"examplePairings":    "ExamplePairing",
+	}[kind]
+	name := pascalCase(map[string]string{
+		"schemas":            label.Title,
+		"contentDescriptors": label.Name,
+		"errors":             label.Message,
+		"examplePairings":    label.Name,
+	}[kind])
+	sum := sha256.Sum256([]byte(canon))
+	hash := hex.EncodeToString(sum[:4])
+	if name == "" {
+		name = origin
+	}
+	if name == "" {
+		name = fallback
+	}
+	base := name
+	for i := 1; component(c, kind, name) != nil; i++ {
+		name = base + hash
+		if i > 1 {
+			name += strconv.Itoa(i)
+		}
+	}
+	return name, nil
+}
+
+func pascalCase(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func componentNames(c *v1_4.Components) map[string][]string {
+	names := map[string][]string{}
+	if c == nil {
+		return names
+	}
+	if c.Schemas != nil {
+		names["schemas"] = sorted.Keys(*c.Schemas)
+	}
+	if c.ContentDescriptors != nil {
+		names["contentDescriptors"] = sorted.Keys(*c.ContentDescriptors)
+	}
+	if c.Errors != nil {
+		names["errors"] = sorted.Keys(*c.Errors)
+	}
+	if c.ExamplePairings != nil {
+		names["examplePairings"] = sorted.Keys(*c.ExamplePairings)
+	}
+	return names
+}
+
+// component returns the component of the given kind and name, or nil.
+func component(c *v1_4.Components, kind, name string) interface{} {
+	if c == nil {
+		return nil
+	}
+	switch kind {
+	case "schemas":
+		if value, ok := entry(c.Schemas, name); ok {
+			return value
+		}
+	case "contentDescriptors":
+		if value, ok := entry(c.ContentDescriptors, name); ok {
+			return value
+		}
+	case "errors":
+		if value, ok := entry(c.Errors, name); ok {
+			return value
+		}
+	case "examplePairings":
+		if value, ok := entry(c.ExamplePairings, name); ok {
+			return value
+		}
+	}
+	return nil
+}
+
+func entry[M ~map[string]V, V any](m *M, name string) (*V, bool) {
+	if m == nil {
+		return nil, false
+	}
+	value, ok := (*m)[name]
+	return &value, ok
+}
+
+func setComponent(c *v1_4.Components, kind, name string, value interface{}) {
+	switch kind {
+	case "schemas":
+		c.Schemas = setEntry(c.Schemas, name, value.(*v1_4.JSONSchema))
+	case "contentDescriptors":
+		c.ContentDescriptors = setEntry(c.ContentDescriptors, name, value.(*v1_4.ContentDescriptorObject))
+	case "errors":
+		c.Errors = setEntry(c.Errors, name, value.(*v1_4.ErrorObject))
+	case "examplePairings":
+		c.ExamplePairings = setEntry(c.ExamplePairings, name, value.(*v1_4.ExamplePairingObject))
+	}
+}
+
+func setEntry[M ~map[string]V, V any](m *M, name string, value *V) *M {
+	if m == nil {
+		m = new(M)
+		*m = M{}
+	}
+	(*m)[name] = *value
+	return m
+}
@@ -0,0 +1,178 @@
+package resolve
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// bundle bundles the document data holds, returning it as JSON.
+func bundle(t *testing.T, data string, options BundleOptions) (*v1_4.OpenrpcDocument, string) {
+	t.Helper()
+	bundled, err := Bundle(decodeDocument(t, data), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(bundled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundled, string(out)
+}
+
+func schemaNames(doc *v1_4.OpenrpcDocument) []string {
+	var names []string
+	if doc.Components != nil && doc.Components.Schemas != nil {
+		for name := range *doc.Components.Schemas {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBundleWithoutHoisting(t *testing.T) {
+	bundled, _ := bundle(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Single", "version": "1.0.0"},
+		"methods": [{"name": "one", "params": [], "result": {"name": "r", "schema": {"type": "object", "properties": {"a": {"type": "string"}}}}}]
+	}`, BundleOptions{})
+	if bundled.Components != nil {
+		t.Errorf("Components = %+v, want none when nothing is hoisted", bundled.Components)
+	}
+}
+
+const repeatedDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "get_pet",
+			"params": [{"name": "id", "schema": {"type": "integer", "minimum": 1}}],
+			"result": {"name": "pet", "schema": {
+				"type": "object",
+				"properties": {
+					"owner": {"type": "object", "properties": {"name": {"type": "string", "minLength": 1}}},
+					"tag": {"title": "Tag label", "type": "string", "maxLength": 20}
+				}
+			}},
+			"errors": [{"code": 404, "message": "not found"}]
+		},
+		{
+			"name": "list_pets",
+			"params": [],
+			"result": {"name": "pets", "schema": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {
+						"owner": {"type": "object", "properties": {"name": {"type": "string", "minLength": 1}}},
+						"tag": {"title": "Tag label", "type": "string", "maxLength": 20}
+					}
+				}
+			}},
+			"errors": [{"code": 404, "message": "not found"}]
+		},
+		{
+			"name": "find_pet",
+			"params": [{"name": "id", "schema": {"type": "integer", "minimum": 1}}],
+			"result": {"name": "pet", "schema": {"type": "null"}}
+		}
+	]
+}`
+
+func TestBundleNames(t *testing.T) {
+	bundled, out := bundle(t, repeatedDoc, BundleOptions{})
+	if got, want := schemaNames(bundled), []string{"Pet"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("schemas = %q, want %q", got, want)
+	}
+	if bundled.Components.ContentDescriptors == nil || (*bundled.Components.ContentDescriptors)["Id"].Name == nil {
+		t.Errorf("content descriptors = %+v, want Id", bundled.Components.ContentDescriptors)
+	}
+	if bundled.Components.Errors == nil || (*bundled.Components.Errors)["NotFound"].Code == nil {
+		t.Errorf("errors = %+v, want NotFound", bundled.Components.Errors)
+	}
+	for _, ref := range []string{
+		`"$ref":"#/components/schemas/Pet"`,
+		`"$ref":"#/components/contentDescriptors/Id"`,
+		`"$ref":"#/components/errors/NotFound"`,
+	} {
+		if !strings.Contains(out, ref) {
+			t.Errorf("%s missing from %s", ref, out)
+		}
+	}
+}
+
+func TestBundleNamesNestedValues(t *testing.T) {
+	if bundled, _ := bundle(t, repeatedDoc, BundleOptions{MinOccurrences: 3}); bundled.Components != nil {
+		t.Errorf("MinOccurrences 3: Components = %+v, want none", bundled.Components)
+	}
+	bundled, out := bundle(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"methods": [
+			{"name": "a", "params": [], "result": {"name": "r", "schema": {"type": "object", "properties": {
+				"id": {"type": "integer"},
+				"owner": {"type": "object", "properties": {"name": {"type": "string", "minLength": 1}}},
+				"tag": {"title": "Tag label", "type": "string", "maxLength": 20}
+			}}}},
+			{"name": "b", "params": [], "result": {"name": "s", "schema": {"type": "object", "properties": {
+				"nick": {"type": "string"},
+				"owner": {"type": "object", "properties": {"name": {"type": "string", "minLength": 1}}},
+				"tag": {"title": "Tag label", "type": "string", "maxLength": 20}
+			}}}}
+		]
+	}`, BundleOptions{})
+	if got, want := schemaNames(bundled), []string{"Owner", "TagLabel"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("schemas = %q, want %q", got, want)
+	}
+	if n := strings.Count(out, `"owner":{"$ref":"#/components/schemas/Owner"}`); n != 2 {
+		t.Errorf("%d references to Owner, want 2: %s", n, out)
+	}
+}
+
+func TestBundleOrigins(t *testing.T) {
+	const email = `{"type": "string", "format": "email", "description": "The email address of a user"}`
+	bundled, _ := bundle(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Names", "version": "1.0.0"},
+		"methods": [
+			{"name": "a", "params": [], "result": {"name": "user list", "schema": {"type": "array", "items": `+email+`}}},
+			{"name": "b", "params": [], "result": {"name": "emails", "schema": {"type": "array", "items": `+email+`}}},
+			{"name": "c", "params": [], "result": {"name": "other", "schema": {"type": "object", "additionalProperties": `+email+`}}}
+		],
+		"components": {"schemas": {"OtherValue": {"type": "boolean"}}}
+	}`, BundleOptions{})
+	// The arrays are hoisted first, being the largest, and named after the content
+	// descriptor they were first found in. The email schema is then found in the result
+	// of c, as its values, and within the new component, but OtherValue is taken.
+	names := schemaNames(bundled)
+	if len(names) != 3 || names[0] != "OtherValue" || !strings.HasPrefix(names[1], "OtherValue") || names[2] != "UserList" {
+		t.Errorf("schemas = %q, want OtherValue, OtherValue with a hash and UserList", names)
+	}
+}
+
+func TestBundleReusesComponents(t *testing.T) {
+	bundled, out := bundle(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Reuse", "version": "1.0.0"},
+		"methods": [
+			{"name": "a", "params": [], "result": {"name": "r", "schema": {"type": "string", "format": "uuid", "description": "An identifier"}}},
+			{"name": "b", "params": [], "result": {"name": "s", "schema": {"$id": "https://example.com/id", "type": "string", "format": "uuid", "description": "An identifier"}}}
+		],
+		"components": {"schemas": {"Uuid": {"type": "string", "format": "uuid", "description": "An identifier"}}}
+	}`, BundleOptions{})
+	if got, want := schemaNames(bundled), []string{"Uuid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("schemas = %q, want %q", got, want)
+	}
+	if !strings.Contains(out, `"result":{"name":"r","schema":{"$ref":"#/components/schemas/Uuid"}}`) {
+		t.Errorf("single copy of a component not replaced: %s", out)
+	}
+	if !strings.Contains(out, `"$id":"https://example.com/id"`) {
+		t.Errorf("schema with an $id was moved: %s", out)
+	}
+}
@@ -0,0 +1,228 @@
+package resolve
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// DereferenceOptions controls how Dereference handles recursive schemas.
+type DereferenceOptions struct {
+	// MaxDepth is the number of times a schema is inlined within itself. Once reached,
+	// the recursive reference is kept in place, written as an absolute URI when it
+	// points outside the document.
+	MaxDepth int
+	// Truncate replaces recursive references past MaxDepth with an empty schema,
+	// which accepts any value, instead of keeping them.
+	Truncate bool
+}
+
+// Dereference returns a deep copy of the document in which every Reference Object is
+// replaced by its target and every schema "$ref" is inlined, including those of the
+// documents it refers to. Components are kept, dereferenced as well, so that the
+// references recursive schemas keep remain valid. When some references cannot be
+// resolved the returned error is an Errors value listing each of them by path.
+func (r *Resolver) Dereference(options DereferenceOptions) (*v1_4.OpenrpcDocument, error) {
+	data, err := json.Marshal(r.doc)
+	if err != nil {
+		return nil, err
+	}
+	var doc v1_4.OpenrpcDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	d := &dereferencer{r: r, options: options}
+	d.document(&doc)
+	if len(d.errs) > 0 {
+		return nil, d.errs
+	}
+	return &doc, nil
+}
+
+type dereferencer struct {
+	r       *Resolver
+	options DereferenceOptions
+	errs    Errors
+}
+
+func (d *dereferencer) document(doc *v1_4.OpenrpcDocument) {
+	base := d.r.base
+	if doc.Methods != nil {
+		for i := range *doc.Methods {
+			path := "/methods/" + strconv.Itoa(i)
+			if m, base, ok := resolveRef(d, path, base, "methods", &(*doc.Methods)[i]); ok {
+				d.method(path, base, m)
+			}
+		}
+	}
+	c := doc.Components
+	if c == nil {
+		return
+	}
+	if c.Schemas != nil {
+		for _, name := range sorted.Keys(*c.Schemas) {
+			schema := (*c.Schemas)[name]
+			path := "/components/schemas/" + source.EscapeToken(name)
+			uri := base.ResolveReference(&url.URL{Fragment: path})
+			d.schema(path, base, &schema, []string{uri.String()})
+			(*c.Schemas)[name] = schema
+		}
+	}
+	if c.ContentDescriptors != nil {
+		for _, name := range sorted.Keys(*c.ContentDescriptors) {
+			descriptor := (*c.ContentDescriptors)[name]
+			d.contentDescriptor("/components/contentDescriptors/"+source.EscapeToken(name), base, &descriptor)
+			(*c.ContentDescriptors)[name] = descriptor
+		}
+	}
+	if c.ExamplePairings != nil {
+		for _, name := range sorted.Keys(*c.ExamplePairings) {
+			pairing := (*c.ExamplePairings)[name]
+			d.examplePairing("/components/examplePairings/"+source.EscapeToken(name), base, &pairing)
+			(*c.ExamplePairings)[name] = pairing
+		}
+	}
+}
+
+func (d *dereferencer) method(path string, base *url.URL, m *v1_4.MethodObject) {
+	if m.Tags != nil {
+		for i := range *m.Tags {
+			resolveRef(d, path+"/tags/"+strconv.Itoa(i), base, "tags", &(*m.Tags)[i])
+		}
+	}
+	if m.Params != nil {
+		for i := range *m.Params {
+			path := path + "/params/" + strconv.Itoa(i)
+			if c, base, ok := resolveRef(d, path, base, "contentDescriptors", &(*m.Params)[i]); ok {
+				d.contentDescriptor(path, base, c)
+			}
+		}
+	}
+	if m.Result != nil {
+		if c, base, ok := resolveRef(d, path+"/result", base, "contentDescriptors", m.Result); ok {
+			d.contentDescriptor(path+"/result", base, c)
+		}
+	}
+	if m.Errors != nil {
+		for i := range *m.Errors {
+			resolveRef(d, path+"/errors/"+strconv.Itoa(i), base, "errors", &(*m.Errors)[i])
+		}
+	}
+	if m.Links != nil {
+		for i := range *m.Links {
+			resolveRef(d, path+"/links/"+strconv.Itoa(i), base, "links", &(*m.Links)[i])
+		}
+	}
+	if m.Examples != nil {
+		for i := range *m.Examples {
+			path := path + "/examples/" + strconv.Itoa(i)
+			if p, base, ok := resolveRef(d, path, base, "examplePairings", &(*m.Examples)[i]); ok {
+				d.examplePairing(path, base, p)
+			}
+		}
+	}
+}
+
+func (d *dereferencer) examplePairing(path string, base *url.URL, p *v1_4.ExamplePairingObject) {
+	if p.Params != nil {
+		for i := range *p.Params {
+			resolveRef(d, path+"/params/"+strconv.Itoa(i), base, "examples", &(*p.Params)[i])
+		}
+	}
+	if p.Result != nil {
+		resolveRef(d, path+"/result", base, "examples", p.Result)
+	}
+}
+
+func (d *dereferencer) contentDescriptor(path string, base *url.URL, c *v1_4.ContentDescriptorObject) {
+	if c.Schema != nil {
+		schema := v1_4.JSONSchema(*c.Schema)
+		d.schema(path+"/schema", base, &schema, nil)
+		*c.Schema = v1_4.ContentDescriptorObjectSchema(schema)
+	}
+}
+
+// resolveRef replaces the reference held by o with its target. It returns the value o
+// holds afterwards along with its base URI, or false when there is none.
+func resolveRef[T any](d *dereferencer, path string, base *url.URL, kind string, o *v1_4.OrRef[T]) (*T, *url.URL, bool) {
+	if !o.IsRef() {
+		return o.Value(), base, o.Value() != nil
+	}
+	ref := deref.String(o.Ref().Ref)
+	target, targetBase, err := d.r.lookup(base, ref, kind, map[string]bool{})
+	if err != nil {
+		d.errs = append(d.errs, d.r.pathError(path, ref, err))
+		return nil, nil, false
+	}
+	value := target.(*T)
+	*o = v1_4.NewValue(*value)
+	return o.Value(), targetBase, true
+}
+
+// schema inlines the references of s. The stack holds the URIs of the schemas being
+// inlined around s, to detect recursion.
+func (d *dereferencer) schema(path string, base *url.URL, s *v1_4.JSONSchema, stack []string) {
+	if s.JSONSchemaObject == nil {
+		return
+	}
+	base = schemaBase(base, s.JSONSchemaObject)
+	if s.JSONSchemaObject.Ref != nil {
+		ref := string(*s.JSONSchemaObject.Ref)
+		parsed, err := url.Parse(ref)
+		if err != nil {
+			d.errs = append(d.errs, d.r.pathError(path, ref, ErrUnsupported))
+			return
+		}
+		uri := base.ResolveReference(parsed)
+		if count(stack, uri.String()) > d.options.MaxDepth {
+			d.stop(s, uri)
+			return
+		}
+		target, targetBase, err := d.r.lookup(base, ref, "schemas", map[string]bool{})
+		if err != nil {
+			d.errs = append(d.errs, d.r.pathError(path, ref, err))
+			return
+		}
+		*s = *target.(*v1_4.JSONSchema)
+		if s.JSONSchemaObject == nil {
+			return
+		}
+		base = schemaBase(targetBase, s.JSONSchemaObject)
+		stack = append(stack[:len(stack):len(stack)], uri.String())
+	}
+	eachSubschema(s.JSONSchemaObject, func(tokens []string, child *v1_4.JSONSchema) {
+		childPath := path
+		for _, token := range tokens {
+			childPath += "/" + source.EscapeToken(token)
+		}
+		d.schema(childPath, base, child, stack)
+	})
+}
+
+// stop ends the inlining of a recursive schema at s, which refers to uri.
+func (d *dereferencer) stop(s *v1_4.JSONSchema, uri *url.URL) {
+	if d.options.Truncate {
+		*s = v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{}}
+		return
+	}
+	ref := v1_4.Ref(uri.String())
+	if withoutFragment(uri).String() == d.r.base.String() {
+		ref = v1_4.Ref("#" + uri.EscapedFragment())
+	}
+	*s = v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{Ref: &ref}}
+}
+
+func count(stack []string, uri string) int {
+	n := 0
+	for _, s := range stack {
+		if s == uri {
+			n++
+		}
+	}
+	return n
+}
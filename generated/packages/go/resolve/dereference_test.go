@@ -0,0 +1,126 @@
+package resolve
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+func TestDereference(t *testing.T) {
+	doc := decodeDocument(t, internalDoc)
+	before, _ := json.Marshal(doc)
+	deref, err := New(doc).Dereference(DereferenceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := json.Marshal(doc); string(after) != string(before) {
+		t.Error("Dereference modified the document")
+	}
+	if refs, err := New(deref).All(); len(refs) != 0 || err != nil {
+		t.Errorf("references left: %v, %v", refs, err)
+	}
+
+	method := (*deref.Methods)[0].Value()
+	if got := *(*method.Params)[0].Value().Name; got != "id" {
+		t.Errorf("param name = %q", got)
+	}
+	if got := *(*method.Errors)[0].Value().Code; got != 404 {
+		t.Errorf("error code = %d", got)
+	}
+	pairing := (*method.Examples)[0].Value()
+	if got := *(*pairing.Params)[0].Value().Value; got != 7.0 {
+		t.Errorf("example value = %v", got)
+	}
+	result := v1_4.JSONSchema(*method.Result.Value().Schema)
+	id := (*result.JSONSchemaObject.Properties)["id"]
+	if got := id.GetTypes(); !reflect.DeepEqual(got, []string{"integer"}) {
+		t.Errorf("result id types = %v, want the integer schema at the end of the chain", got)
+	}
+	if _, ok := (*deref.Components.Schemas)["a/b"]; !ok {
+		t.Error("components were dropped")
+	}
+}
+
+const recursiveDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Lists", "version": "1.0.0"},
+	"methods": [
+		{"name": "head", "params": [], "result": {"name": "list", "schema": {"$ref": "#/components/schemas/Node"}}}
+	],
+	"components": {
+		"schemas": {
+			"Node": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Node"}}}
+		}
+	}
+}`
+
+// next returns the schema of the "next" property of s.
+func next(t *testing.T, s v1_4.JSONSchema) v1_4.JSONSchema {
+	t.Helper()
+	if s.JSONSchemaObject == nil || s.JSONSchemaObject.Properties == nil {
+		t.Fatalf("%+v has no properties", s)
+	}
+	return (*s.JSONSchemaObject.Properties)["next"]
+}
+
+func TestDereferenceRecursive(t *testing.T) {
+	result := func(options DereferenceOptions) v1_4.JSONSchema {
+		deref, err := New(decodeDocument(t, recursiveDoc)).Dereference(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v1_4.JSONSchema(*(*deref.Methods)[0].Value().Result.Value().Schema)
+	}
+	ref := func(s v1_4.JSONSchema) string {
+		if s.JSONSchemaObject == nil || s.JSONSchemaObject.Ref == nil {
+			return ""
+		}
+		return string(*s.JSONSchemaObject.Ref)
+	}
+
+	s := result(DereferenceOptions{})
+	if got := ref(next(t, s)); got != "#/components/schemas/Node" {
+		t.Errorf("MaxDepth 0: next = %q, want the recursive reference kept", got)
+	}
+	s = result(DereferenceOptions{MaxDepth: 1})
+	if got := ref(next(t, next(t, s))); got != "#/components/schemas/Node" {
+		t.Errorf("MaxDepth 1: next/next = %q, want the recursive reference kept", got)
+	}
+	s = result(DereferenceOptions{MaxDepth: 1, Truncate: true})
+	if got := next(t, next(t, s)); !reflect.DeepEqual(got, v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{}}) {
+		t.Errorf("Truncate: next/next = %+v, want an empty schema", got)
+	}
+}
+
+func TestDereferenceErrors(t *testing.T) {
+	doc := decodeDocument(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Broken", "version": "1.0.0"},
+		"methods": [
+			{
+				"name": "broken",
+				"params": [{"$ref": "#/components/contentDescriptors/Missing"}],
+				"result": {"name": "r", "schema": {"properties": {"a": {"$ref": "#/components/schemas/Missing"}}}}
+			}
+		]
+	}`)
+	_, err := New(doc).Dereference(DereferenceOptions{})
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got %v, want Errors", err)
+	}
+	var got []string
+	for _, e := range errs {
+		if !errors.Is(e, ErrDangling) {
+			t.Errorf("%v: want ErrDangling", e)
+		}
+		got = append(got, e.Path)
+	}
+	want := []string{"/methods/0/params/0", "/methods/0/result/schema/properties/a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,186 @@
+package resolve
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+)
+
+// errNoLoader is returned for references to other documents when there is no Loader.
+var errNoLoader = fmt.Errorf("%w: no loader for", ErrUnsupported)
+
+// cache holds the JSON of every document a Resolver has read. Documents and the
+// schemas within them that declare an "$id" are resources, keyed by absolute URI.
+type cache struct {
+	mu        sync.Mutex
+	resources map[string]resource
+}
+
+// resource is a JSON value identified by a URI, along with the base URI its
+// references resolve against.
+type resource struct {
+	node interface{}
+	base *url.URL
+}
+
+func newCache() *cache {
+	return &cache{resources: map[string]resource{}}
+}
+
+// locate finds the JSON value uri identifies, loading its document when needed.
+// It returns the value, the base URI in effect where it appears and the JSON pointer
+// tokens leading to it from the root of the resource holding it. The base does not
+// include the value's own "$id", which callers apply as they do for any schema.
+func (r *Resolver) locate(uri *url.URL) (interface{}, *url.URL, []string, error) {
+	r.cache.mu.Lock()
+	defer r.cache.mu.Unlock()
+	if err := r.readRoot(); err != nil {
+		return nil, nil, nil, err
+	}
+	if res, ok := r.cache.resources[uri.String()]; ok {
+		return res.node, res.base, nil, nil
+	}
+	document := withoutFragment(uri)
+	res, ok := r.cache.resources[document.String()]
+	if !ok {
+		if r.loader == nil {
+			return nil, nil, nil, fmt.Errorf("%w %s", errNoLoader, document)
+		}
+		data, err := r.loader.Load(document)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: loading %s: %v", ErrDangling, document, err)
+		}
+		if err := r.read(data, document); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: reading %s: %v", ErrDangling, document, err)
+		}
+		res = r.cache.resources[document.String()]
+	}
+	if uri.Fragment == "" {
+		return res.node, res.base, nil, nil
+	}
+	if !strings.HasPrefix(uri.Fragment, "/") {
+		return nil, nil, nil, ErrDangling
+	}
+	tokens := strings.Split(uri.Fragment[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = source.UnescapeToken(token)
+	}
+	node, base := res.node, res.base
+	for _, token := range tokens {
+		if id, ok := schemaID(node); ok && hasLocation(id) {
+			base = withoutFragment(base.ResolveReference(id))
+		}
+		switch value := node.(type) {
+		case map[string]interface{}:
+			child, ok := value[token]
+			if !ok {
+				return nil, nil, nil, ErrDangling
+			}
+			node = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(value) {
+				return nil, nil, nil, ErrDangling
+			}
+			node = value[i]
+		default:
+			return nil, nil, nil, ErrDangling
+		}
+	}
+	return node, base, tokens, nil
+}
+
+// readRoot adds the Resolver's own document to the cache, once.
+func (r *Resolver) readRoot() error {
+	if _, ok := r.cache.resources[r.base.String()]; ok {
+		return nil
+	}
+	data, err := json.Marshal(r.doc)
+	if err != nil {
+		return err
+	}
+	return r.read(data, r.base)
+}
+
+// read adds the document at uri to the cache, along with the schemas it identifies.
+func (r *Resolver) read(data []byte, uri *url.URL) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return err
+	}
+	r.cache.resources[uri.String()] = resource{node: root, base: uri}
+	r.index(root, uri)
+	return nil
+}
+
+// index registers every value below node that declares an "$id" as a resource.
+// Plain name fragments such as "#user" are registered along with their fragment.
+// Resources keep the base URI in effect where they appear, as locate returns it.
+func (r *Resolver) index(node interface{}, base *url.URL) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if id, ok := schemaID(value); ok {
+			uri := base.ResolveReference(id)
+			if hasLocation(id) {
+				r.register(withoutFragment(uri), value, base)
+			}
+			if uri.Fragment != "" {
+				r.register(uri, value, base)
+			}
+			if hasLocation(id) {
+				base = withoutFragment(uri)
+			}
+		}
+		for _, child := range value {
+			r.index(child, base)
+		}
+	case []interface{}:
+		for _, child := range value {
+			r.index(child, base)
+		}
+	}
+}
+
+// schemaID returns the "$id" node declares, if any. Identifiers with a JSON pointer
+// fragment are ignored, as draft-07 does not allow them.
+func schemaID(node interface{}) (*url.URL, bool) {
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	s, ok := object["$id"].(string)
+	if !ok {
+		return nil, false
+	}
+	id, err := url.Parse(s)
+	if err != nil || strings.HasPrefix(id.Fragment, "/") {
+		return nil, false
+	}
+	return id, true
+}
+
+// hasLocation reports whether id names more than a fragment, changing the base URI.
+func hasLocation(id *url.URL) bool {
+	return id.Scheme != "" || id.Host != "" || id.Path != "" || id.Opaque != ""
+}
+
+// register adds a resource unless one already has the same URI.
+func (r *Resolver) register(uri *url.URL, node interface{}, base *url.URL) {
+	if _, ok := r.cache.resources[uri.String()]; !ok {
+		r.cache.resources[uri.String()] = resource{node: node, base: base}
+	}
+}
+
+func withoutFragment(uri *url.URL) *url.URL {
+	u := *uri
+	u.Fragment, u.RawFragment = "", ""
+	return &u
+}
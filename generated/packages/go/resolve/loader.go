@@ -0,0 +1,79 @@
+package resolve
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Loader loads the documents references point at.
+type Loader interface {
+	// Load returns the contents of the document at uri, which has no fragment.
+	Load(uri *url.URL) ([]byte, error)
+}
+
+// LoaderFunc adapts a function to the Loader interface.
+type LoaderFunc func(uri *url.URL) ([]byte, error)
+
+// Load calls f(uri).
+func (f LoaderFunc) Load(uri *url.URL) ([]byte, error) {
+	return f(uri)
+}
+
+// FSLoader loads documents from a file system. URIs without a scheme or with the
+// "file" scheme name the slash-separated path of a file within FS; a leading slash
+// is ignored, as relative references resolve to absolute paths.
+type FSLoader struct {
+	FS fs.FS
+}
+
+// Load implements the Loader interface.
+func (l FSLoader) Load(uri *url.URL) ([]byte, error) {
+	if uri.Scheme != "" && uri.Scheme != "file" {
+		return nil, fmt.Errorf("%w: %s is not a file URI", ErrUnsupported, uri)
+	}
+	return fs.ReadFile(l.FS, strings.TrimPrefix(path.Clean("/"+uri.Path), "/"))
+}
+
+// HTTPLoader loads documents over HTTP and HTTPS.
+type HTTPLoader struct {
+	// Client sends the requests. When nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Load implements the Loader interface.
+func (l HTTPLoader) Load(uri *url.URL) ([]byte, error) {
+	if uri.Scheme != "http" && uri.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s is not an HTTP URI", ErrUnsupported, uri)
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(uri.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", uri, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// SchemeLoader dispatches to a Loader by URI scheme. The empty scheme is used for
+// references without one.
+type SchemeLoader map[string]Loader
+
+// Load implements the Loader interface.
+func (l SchemeLoader) Load(uri *url.URL) ([]byte, error) {
+	loader, ok := l[uri.Scheme]
+	if !ok {
+		return nil, fmt.Errorf("%w: no loader for scheme %q", ErrUnsupported, uri.Scheme)
+	}
+	return loader.Load(uri)
+}
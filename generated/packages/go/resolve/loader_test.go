@@ -0,0 +1,182 @@
+package resolve
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// externalDoc refers to documents next to it, at api/openrpc.json.
+const externalDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Petstore", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "get_pet",
+			"params": [{"$ref": "common.json#/components/contentDescriptors/PetId"}],
+			"result": {"name": "pet", "schema": {"$ref": "schemas/pet.json"}},
+			"errors": [{"$ref": "common.json#/components/errors/NotFound"}]
+		}
+	]
+}`
+
+// externalFiles are the documents externalDoc refers to, by path.
+var externalFiles = map[string]string{
+	"/api/common.json": `{
+		"components": {
+			"contentDescriptors": {"PetId": {"name": "id", "schema": {"$ref": "schemas/pet.json#/properties/id"}}},
+			"errors": {"NotFound": {"code": 404, "message": "not found"}}
+		}
+	}`,
+	"/api/schemas/pet.json": `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"owner": {"$ref": "#/definitions/Owner"}
+		},
+		"definitions": {
+			"Owner": {"$id": "people/", "properties": {"name": {"$ref": "name.json"}}}
+		}
+	}`,
+	"/api/schemas/people/name.json": `{"type": "string"}`,
+}
+
+// server serves externalFiles, counting the requests for each of them.
+func server(t *testing.T) (*httptest.Server, map[string]int) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requests[req.URL.Path]++
+		mu.Unlock()
+		file, ok := externalFiles[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write([]byte(file))
+	}))
+	t.Cleanup(s.Close)
+	return s, requests
+}
+
+func TestHTTPLoader(t *testing.T) {
+	s, requests := server(t)
+	base, _ := url.Parse(s.URL + "/api/openrpc.json")
+	doc := decodeDocument(t, externalDoc)
+	r := New(doc, WithBase(base), WithLoader(HTTPLoader{Client: s.Client()}))
+	method := (*doc.Methods)[0].Value()
+
+	param, err := Resolve(r, (*method.Params)[0])
+	if err != nil || *param.Name != "id" {
+		t.Fatalf("param = %+v, %v", param, err)
+	}
+	id, err := r.Schema((*v1_4.JSONSchema)(param.Schema))
+	if err != nil || !reflect.DeepEqual(id.GetTypes(), []string{"integer"}) {
+		t.Errorf("param schema = %+v, %v, want the integer schema in schemas/pet.json", id, err)
+	}
+	e, err := Resolve(r, (*method.Errors)[0])
+	if err != nil || *e.Code != 404 {
+		t.Errorf("error = %+v, %v", e, err)
+	}
+	if requests["/api/common.json"] != 1 || requests["/api/schemas/pet.json"] != 1 {
+		t.Errorf("requests = %v, want each document read once", requests)
+	}
+
+	if _, err := r.Lookup("missing.json"); !errors.Is(err, ErrDangling) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("missing document: got %v, want ErrDangling reporting the status", err)
+	}
+	if _, err := (HTTPLoader{}).Load(&url.URL{Scheme: "ftp", Host: "example.com"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("ftp URI: got %v, want ErrUnsupported", err)
+	}
+}
+
+func TestIDChangesBase(t *testing.T) {
+	s, _ := server(t)
+	base, _ := url.Parse(s.URL + "/api/openrpc.json")
+	r := New(decodeDocument(t, externalDoc), WithBase(base), WithLoader(HTTPLoader{Client: s.Client()}))
+
+	name, err := r.Lookup("schemas/pet.json#/definitions/Owner/properties/name")
+	if err != nil || !reflect.DeepEqual(name.(*v1_4.JSONSchema).GetTypes(), []string{"string"}) {
+		t.Errorf("owner name = %+v, %v, want the schema of people/name.json", name, err)
+	}
+	owner, err := r.Lookup("schemas/people/")
+	if err != nil || owner.(*v1_4.JSONSchema).JSONSchemaObject.Properties == nil {
+		t.Errorf("owner by $id = %+v, %v", owner, err)
+	}
+	doc, err := r.Dereference(DereferenceOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := v1_4.JSONSchema(*(*doc.Methods)[0].Value().Result.Value().Schema)
+	name2 := (*(*result.JSONSchemaObject.Properties)["owner"].JSONSchemaObject.Properties)["name"]
+	if got := name2.GetTypes(); !reflect.DeepEqual(got, []string{"string"}) {
+		t.Errorf("inlined owner name types = %v, want the $id of Owner applied once", got)
+	}
+}
+
+func TestFSLoader(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for name, file := range externalFiles {
+		fsys[strings.TrimPrefix(name, "/")] = &fstest.MapFile{Data: []byte(file)}
+	}
+	doc := decodeDocument(t, externalDoc)
+	r := New(doc, WithBase(&url.URL{Path: "api/openrpc.json"}), WithLoader(FSLoader{FS: fsys}))
+
+	refs, err := r.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.URI)
+	}
+	want := []string{
+		"/api/common.json#/components/contentDescriptors/PetId",
+		"/api/schemas/pet.json",
+		"/api/common.json#/components/errors/NotFound",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("URIs = %q, want %q", got, want)
+	}
+	if _, err := r.Lookup("../outside.json"); !errors.Is(err, ErrDangling) {
+		t.Errorf("missing file: got %v, want ErrDangling", err)
+	}
+	if _, err := (FSLoader{FS: fsys}).Load(&url.URL{Scheme: "http", Host: "example.com"}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("http URI: got %v, want ErrUnsupported", err)
+	}
+}
+
+func TestSchemeLoader(t *testing.T) {
+	var loaded []string
+	record := func(scheme string) Loader {
+		return LoaderFunc(func(uri *url.URL) ([]byte, error) {
+			loaded = append(loaded, scheme+" "+uri.String())
+			return []byte(`{"type": "string"}`), nil
+		})
+	}
+	r := New(decodeDocument(t, internalDoc), WithLoader(SchemeLoader{
+		"":      record("file"),
+		"https": record("https"),
+	}))
+	for _, ref := range []string{"local.json", "https://example.com/remote.json"} {
+		if _, err := r.Lookup(ref); err != nil {
+			t.Errorf("%s: %v", ref, err)
+		}
+	}
+	if _, err := r.Lookup("ftp://example.com/x.json"); !errors.Is(err, ErrDangling) || !strings.Contains(err.Error(), `scheme "ftp"`) {
+		t.Errorf("ftp: got %v, want a dangling reference naming the scheme", err)
+	}
+	want := []string{"file /local.json", "https https://example.com/remote.json"}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("loaded %q, want %q", loaded, want)
+	}
+}
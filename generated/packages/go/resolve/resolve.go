@@ -0,0 +1,259 @@
+// Package resolve resolves the references of a 1.4 OpenRPC document.
+//
+// References are either Reference Objects, held by the v1_4.OrRef unions, or the "$ref"
+// keyword of a JSON Schema. Internal references point into the same document, such as
+// "#/components/schemas/User". References to other documents, such as
+// "./schemas/user.json#/definitions/User", are loaded through a Loader and resolved
+// against the URI of the document holding them, taking schema "$id"s into account.
+// 1.3 documents can be resolved after converting them with convert.Upgrade.
+package resolve
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+var (
+	// ErrDangling is returned for references whose target does not exist.
+	ErrDangling = errors.New("dangling reference")
+	// ErrCycle is returned for references that lead back to themselves.
+	ErrCycle = errors.New("reference cycle")
+	// ErrUnsupported is returned for references the resolver cannot follow.
+	ErrUnsupported = errors.New("unsupported reference")
+	// ErrMismatch is returned when a reference points at the wrong kind of object.
+	ErrMismatch = errors.New("reference points at the wrong kind of object")
+)
+
+// Error describes a reference that could not be resolved.
+type Error struct {
+	// Path is the JSON pointer of the referencing object within the document.
+	Path string
+	Ref  string
+	Err  error
+	// Position locates the referencing object in the document's text. It is only set
+	// for errors with a Path, by Resolvers created WithSource.
+	Position *source.Position
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Path == "":
+		return fmt.Sprintf("%s: %v", e.Ref, e.Err)
+	case e.Position != nil:
+		return fmt.Sprintf("%s: %s: %s: %v", e.Position, e.Path, e.Ref, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.Path, e.Ref, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errors collects every reference of a document that could not be resolved.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Resolver resolves references against a document and the documents it refers to.
+// The document must not be modified while the Resolver is in use.
+type Resolver struct {
+	doc    *v1_4.OpenrpcDocument
+	base   *url.URL
+	loader Loader
+	source *source.Map
+	cache  *cache
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithBase sets the URI the document was loaded from. Relative references are
+// resolved against it.
+func WithBase(base *url.URL) Option {
+	return func(r *Resolver) {
+		r.base = base.ResolveReference(&url.URL{})
+	}
+}
+
+// WithLoader sets the Loader used for references to other documents. Without one,
+// such references are reported as unsupported.
+func WithLoader(loader Loader) Option {
+	return func(r *Resolver) {
+		r.loader = loader
+	}
+}
+
+// WithSource sets the positions of the document's values, as indexed from the text
+// it was decoded from, locating the errors of references within it.
+func WithSource(m *source.Map) Option {
+	return func(r *Resolver) {
+		r.source = m
+	}
+}
+
+// New returns a Resolver for doc.
+func New(doc *v1_4.OpenrpcDocument, options ...Option) *Resolver {
+	r := &Resolver{doc: doc, base: &url.URL{}, cache: newCache()}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Lookup returns the target of a reference such as "#/components/errors/NotFound",
+// resolved against the document's URI. Targets within "components/<kind>/<name>" of an
+// OpenRPC document are returned as the matching *v1_4.JSONSchema,
+// *v1_4.ContentDescriptorObject, *v1_4.ErrorObject, *v1_4.LinkObject,
+// *v1_4.ExampleObject, *v1_4.ExamplePairingObject or *v1_4.TagObject; any other target
+// is returned as a *v1_4.JSONSchema. Schema targets that are themselves references are
+// followed to the schema they name. Targets are copies decoded from the document.
+func (r *Resolver) Lookup(ref string) (interface{}, error) {
+	target, _, err := r.lookup(r.base, ref, "", map[string]bool{})
+	return target, err
+}
+
+// Resolve returns the object held by o, looking it up when o is a reference.
+func Resolve[T any](r *Resolver, o v1_4.OrRef[T]) (*T, error) {
+	if !o.IsRef() {
+		return o.Value(), nil
+	}
+	ref := deref.String(o.Ref().Ref)
+	target, _, err := r.lookup(r.base, ref, kindOf(new(T)), map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	value, ok := target.(*T)
+	if !ok {
+		return nil, &Error{Ref: ref, Err: fmt.Errorf("%w: got %T, want %T", ErrMismatch, target, value)}
+	}
+	return value, nil
+}
+
+// Schema returns s, or the schema it names when it is a "$ref" schema.
+func (r *Resolver) Schema(s *v1_4.JSONSchema) (*v1_4.JSONSchema, error) {
+	ref := schemaRef(s)
+	if ref == "" {
+		return s, nil
+	}
+	target, _, err := r.lookup(r.base, ref, "schemas", map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return target.(*v1_4.JSONSchema), nil
+}
+
+// lookup resolves ref against base and decodes its target as the given kind of
+// component, inferring the kind from the target's location when it is empty.
+// It also returns the base URI in effect where the target appears, which its own
+// "$id", if any, refines for the references within it.
+func (r *Resolver) lookup(base *url.URL, ref, kind string, seen map[string]bool) (interface{}, *url.URL, error) {
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return nil, nil, &Error{Ref: ref, Err: fmt.Errorf("%w: %v", ErrUnsupported, err)}
+	}
+	uri := base.ResolveReference(parsed)
+	if seen[uri.String()] {
+		return nil, nil, &Error{Ref: ref, Err: ErrCycle}
+	}
+	seen[uri.String()] = true
+	node, nodeBase, tokens, err := r.locate(uri)
+	if err != nil {
+		return nil, nil, &Error{Ref: ref, Err: err}
+	}
+	located := ""
+	if len(tokens) == 3 && tokens[0] == "components" && kinds[tokens[1]] != nil {
+		located = tokens[1]
+	}
+	switch {
+	case kind == "" && located == "":
+		kind = "schemas"
+	case kind == "":
+		kind = located
+	case located != "" && located != kind:
+		return nil, nil, &Error{Ref: ref, Err: fmt.Errorf("%w: got a components/%s entry, want a components/%s entry", ErrMismatch, located, kind)}
+	}
+	target, err := decode(node, kind)
+	if err != nil {
+		return nil, nil, &Error{Ref: ref, Err: err}
+	}
+	if next := schemaRef(asSchema(target)); next != "" {
+		return r.lookup(nodeBase, next, kind, seen)
+	}
+	return target, nodeBase, nil
+}
+
+// kinds creates the value each kind of component decodes into.
+var kinds = map[string]func() interface{}{
+	"schemas":            func() interface{} { return new(v1_4.JSONSchema) },
+	"contentDescriptors": func() interface{} { return new(v1_4.ContentDescriptorObject) },
+	"errors":             func() interface{} { return new(v1_4.ErrorObject) },
+	"links":              func() interface{} { return new(v1_4.LinkObject) },
+	"examples":           func() interface{} { return new(v1_4.ExampleObject) },
+	"examplePairings":    func() interface{} { return new(v1_4.ExamplePairingObject) },
+	"tags":               func() interface{} { return new(v1_4.TagObject) },
+	"methods":            func() interface{} { return new(v1_4.MethodObject) },
+}
+
+// kindOf returns the kind of component target is, or an empty string.
+func kindOf(target interface{}) string {
+	switch target.(type) {
+	case *v1_4.JSONSchema:
+		return "schemas"
+	case *v1_4.ContentDescriptorObject:
+		return "contentDescriptors"
+	case *v1_4.ErrorObject:
+		return "errors"
+	case *v1_4.LinkObject:
+		return "links"
+	case *v1_4.ExampleObject:
+		return "examples"
+	case *v1_4.ExamplePairingObject:
+		return "examplePairings"
+	case *v1_4.TagObject:
+		return "tags"
+	case *v1_4.MethodObject:
+		return "methods"
+	}
+	return ""
+}
+
+func decode(node interface{}, kind string) (interface{}, error) {
+	create, ok := kinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown component kind %q", ErrUnsupported, kind)
+	}
+	bytes, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	target := create()
+	if err := json.Unmarshal(bytes, target); err != nil {
+		return nil, fmt.Errorf("%w: not a components/%s entry: %v", ErrMismatch, kind, err)
+	}
+	return target, nil
+}
+
+func asSchema(target interface{}) *v1_4.JSONSchema {
+	schema, _ := target.(*v1_4.JSONSchema)
+	return schema
+}
+
+func schemaRef(s *v1_4.JSONSchema) string {
+	if s == nil || s.JSONSchemaObject == nil {
+		return ""
+	}
+	return deref.String(s.JSONSchemaObject.Ref)
+}
@@ -0,0 +1,211 @@
+package resolve
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// decodeDocument returns the document data holds, failing t when it is not one.
+func decodeDocument(t *testing.T, data string) *v1_4.OpenrpcDocument {
+	t.Helper()
+	var doc v1_4.OpenrpcDocument
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return &doc
+}
+
+const internalDoc = `{
+	"openrpc": "1.4.0",
+	"info": {"title": "Petstore", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "get_pet",
+			"tags": [{"$ref": "#/components/tags/pets"}],
+			"params": [{"$ref": "#/components/contentDescriptors/PetId"}],
+			"result": {"name": "pet", "schema": {"$ref": "#/components/schemas/Pet"}},
+			"errors": [{"$ref": "#/components/errors/NotFound"}],
+			"links": [{"$ref": "#/components/links/owner"}],
+			"examples": [{"$ref": "#/components/examplePairings/fido"}]
+		}
+	],
+	"components": {
+		"schemas": {
+			"Pet": {"type": "object", "properties": {"id": {"$ref": "#/components/schemas/Id"}}},
+			"Id": {"$ref": "#/components/schemas/a~1b"},
+			"a/b": {"type": "integer"}
+		},
+		"contentDescriptors": {"PetId": {"name": "id", "schema": {"$ref": "#/components/schemas/Id"}}},
+		"errors": {"NotFound": {"code": 404, "message": "not found"}},
+		"links": {"owner": {"method": "get_owner"}},
+		"examples": {"seven": {"name": "seven", "value": 7}},
+		"examplePairings": {"fido": {"name": "fido", "params": [{"$ref": "#/components/examples/seven"}]}},
+		"tags": {"pets": {"name": "pets"}}
+	}
+}`
+
+func TestResolve(t *testing.T) {
+	doc := decodeDocument(t, internalDoc)
+	r := New(doc)
+	method := (*doc.Methods)[0].Value()
+
+	tag, err := Resolve(r, (*method.Tags)[0])
+	if err != nil || *tag.Name != "pets" {
+		t.Errorf("tag = %+v, %v", tag, err)
+	}
+	param, err := Resolve(r, (*method.Params)[0])
+	if err != nil || *param.Name != "id" {
+		t.Errorf("param = %+v, %v", param, err)
+	}
+	e, err := Resolve(r, (*method.Errors)[0])
+	if err != nil || *e.Code != 404 {
+		t.Errorf("error = %+v, %v", e, err)
+	}
+	link, err := Resolve(r, (*method.Links)[0])
+	if err != nil || *link.Method != "get_owner" {
+		t.Errorf("link = %+v, %v", link, err)
+	}
+	pairing, err := Resolve(r, (*method.Examples)[0])
+	if err != nil || *pairing.Name != "fido" {
+		t.Fatalf("example pairing = %+v, %v", pairing, err)
+	}
+	example, err := Resolve(r, (*pairing.Params)[0])
+	if err != nil || *example.Value != 7.0 {
+		t.Errorf("example = %+v, %v", example, err)
+	}
+	result, err := Resolve(r, *method.Result)
+	if err != nil || result != method.Result.Value() {
+		t.Errorf("inline result = %+v, %v, want the value itself", result, err)
+	}
+}
+
+func TestSchemaFollowsChains(t *testing.T) {
+	r := New(decodeDocument(t, internalDoc))
+	ref := v1_4.Ref("#/components/schemas/Id")
+	schema, err := r.Schema(&v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{Ref: &ref}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := schema.GetTypes(); !reflect.DeepEqual(got, []string{"integer"}) {
+		t.Errorf("schema types = %v, want the integer schema Id leads to", got)
+	}
+	inline := &v1_4.JSONSchema{JSONSchemaObject: &v1_4.JSONSchemaObject{}}
+	if got, err := r.Schema(inline); got != inline || err != nil {
+		t.Errorf("inline schema = %v, %v", got, err)
+	}
+}
+
+func TestLookupInfersKind(t *testing.T) {
+	r := New(decodeDocument(t, internalDoc))
+	for ref, want := range map[string]interface{}{
+		"#/components/errors/NotFound":                 &v1_4.ErrorObject{},
+		"#/components/contentDescriptors/PetId":        &v1_4.ContentDescriptorObject{},
+		"#/components/schemas/Pet":                     &v1_4.JSONSchema{},
+		"#/components/schemas/Pet/properties/id":       &v1_4.JSONSchema{},
+		"#/components/contentDescriptors/PetId/schema": &v1_4.JSONSchema{},
+	} {
+		target, err := r.Lookup(ref)
+		if err != nil {
+			t.Errorf("%s: %v", ref, err)
+			continue
+		}
+		if reflect.TypeOf(target) != reflect.TypeOf(want) {
+			t.Errorf("%s: got a %T, want a %T", ref, target, want)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	doc := decodeDocument(t, `{
+		"openrpc": "1.4.0",
+		"info": {"title": "Broken", "version": "1.0.0"},
+		"methods": [
+			{
+				"name": "broken",
+				"params": [{"$ref": "#/components/contentDescriptors/Missing"}],
+				"errors": [{"$ref": "#/components/tags/pets"}],
+				"result": {"name": "loop", "schema": {"$ref": "#/components/schemas/A"}}
+			}
+		],
+		"components": {
+			"schemas": {"A": {"$ref": "#/components/schemas/B"}, "B": {"$ref": "#/components/schemas/A"}},
+			"tags": {"pets": {"name": "pets"}}
+		}
+	}`)
+	r := New(doc)
+	method := (*doc.Methods)[0].Value()
+
+	if _, err := Resolve(r, (*method.Params)[0]); !errors.Is(err, ErrDangling) {
+		t.Errorf("missing param: got %v, want ErrDangling", err)
+	}
+	if _, err := Resolve(r, (*method.Errors)[0]); !errors.Is(err, ErrMismatch) {
+		t.Errorf("tag as error: got %v, want ErrMismatch", err)
+	}
+	if _, err := r.Lookup("#/components/schemas/A"); !errors.Is(err, ErrCycle) {
+		t.Errorf("cycle: got %v, want ErrCycle", err)
+	}
+	if _, err := r.Lookup("other.json#/components/schemas/A"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("external without a loader: got %v, want ErrUnsupported", err)
+	}
+
+	refs, err := r.All()
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("All() error = %v, want Errors", err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	want := []string{
+		"/methods/0/params/0",
+		"/methods/0/result/schema",
+		"/methods/0/errors/0",
+		"/components/schemas/A",
+		"/components/schemas/B",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("error paths = %q, want %q", got, want)
+	}
+	if len(refs) != 0 {
+		t.Errorf("resolved %v, want nothing", refs)
+	}
+	if msg := errs[0].Error(); !strings.HasPrefix(msg, "/methods/0/params/0: #/components/contentDescriptors/Missing: ") {
+		t.Errorf("message = %q", msg)
+	}
+}
+
+func TestAll(t *testing.T) {
+	r := New(decodeDocument(t, internalDoc))
+	refs, err := r.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.Path+" "+ref.URI)
+	}
+	want := []string{
+		"/methods/0/tags/0 #/components/tags/pets",
+		"/methods/0/params/0 #/components/contentDescriptors/PetId",
+		"/methods/0/result/schema #/components/schemas/Pet",
+		"/methods/0/errors/0 #/components/errors/NotFound",
+		"/methods/0/links/0 #/components/links/owner",
+		"/methods/0/examples/0 #/components/examplePairings/fido",
+		"/components/schemas/Id #/components/schemas/a~1b",
+		"/components/schemas/Pet/properties/id #/components/schemas/Id",
+		"/components/contentDescriptors/PetId/schema #/components/schemas/Id",
+		"/components/examplePairings/fido/params/0 #/components/examples/seven",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("All() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if _, ok := refs[0].Target.(*v1_4.TagObject); !ok {
+		t.Errorf("first target is a %T, want a *v1_4.TagObject", refs[0].Target)
+	}
+}
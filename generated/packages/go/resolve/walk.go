@@ -0,0 +1,266 @@
+package resolve
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/sorted"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Reference is a reference found in a document together with its resolved target.
+type Reference struct {
+	// Path is the JSON pointer of the referencing object within the document.
+	Path string
+	Ref  string
+	// URI is Ref resolved against the base URI of the referencing object.
+	URI string
+	// Target is the value Ref resolves to, as returned by Lookup.
+	Target interface{}
+}
+
+// All resolves every reference of the document, in document order. References to
+// other documents are skipped when the Resolver has no Loader. When some references
+// cannot be resolved the returned error is an Errors value listing each of them by path.
+func (r *Resolver) All() ([]Reference, error) {
+	var refs []Reference
+	var errs Errors
+	walker{base: r.base, fn: func(path string, base *url.URL, ref, kind string) {
+		target, _, err := r.lookup(base, ref, kind, map[string]bool{})
+		if errors.Is(err, errNoLoader) {
+			return
+		}
+		if err != nil {
+			errs = append(errs, r.pathError(path, ref, err))
+			return
+		}
+		uri, _ := url.Parse(ref)
+		refs = append(refs, Reference{Path: path, Ref: ref, URI: base.ResolveReference(uri).String(), Target: target})
+	}}.document(r.doc)
+	if len(errs) > 0 {
+		return refs, errs
+	}
+	return refs, nil
+}
+
+// pathError returns err as the error of the reference at path.
+func (r *Resolver) pathError(path, ref string, err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		err = e.Err
+	}
+	located := &Error{Path: path, Ref: ref, Err: err}
+	if r.source != nil {
+		position, _ := r.source.Nearest(path + "/$ref")
+		located.Position = &position
+	}
+	return located
+}
+
+// visitFunc receives the path of a reference, the base URI it resolves against, its
+// value and the kind of component, such as "schemas" or "errors", its target must be.
+type visitFunc func(path string, base *url.URL, ref, kind string)
+
+// walker calls fn for every reference of a document: Reference Objects as well as
+// schema "$ref" keywords. Schemas declaring an "$id" change the base URI of the
+// references within them.
+type walker struct {
+	base *url.URL
+	fn   visitFunc
+}
+
+func (w walker) document(doc *v1_4.OpenrpcDocument) {
+	if doc.Methods != nil {
+		for i, m := range *doc.Methods {
+			path := "/methods/" + strconv.Itoa(i)
+			if w.ref(path, "methods", m.IsRef(), m.Ref()) {
+				w.method(path, m.Value())
+			}
+		}
+	}
+	c := doc.Components
+	if c == nil {
+		return
+	}
+	if c.Schemas != nil {
+		for _, name := range sorted.Keys(*c.Schemas) {
+			schema := (*c.Schemas)[name]
+			w.schema("/components/schemas/"+source.EscapeToken(name), w.base, &schema)
+		}
+	}
+	if c.ContentDescriptors != nil {
+		for _, name := range sorted.Keys(*c.ContentDescriptors) {
+			descriptor := (*c.ContentDescriptors)[name]
+			w.contentDescriptor("/components/contentDescriptors/"+source.EscapeToken(name), &descriptor)
+		}
+	}
+	if c.ExamplePairings != nil {
+		for _, name := range sorted.Keys(*c.ExamplePairings) {
+			pairing := (*c.ExamplePairings)[name]
+			w.examplePairing("/components/examplePairings/"+source.EscapeToken(name), &pairing)
+		}
+	}
+}
+
+func (w walker) method(path string, m *v1_4.MethodObject) {
+	if m.Tags != nil {
+		for i, tag := range *m.Tags {
+			w.ref(path+"/tags/"+strconv.Itoa(i), "tags", tag.IsRef(), tag.Ref())
+		}
+	}
+	if m.Params != nil {
+		for i, param := range *m.Params {
+			w.contentDescriptorOrRef(path+"/params/"+strconv.Itoa(i), param)
+		}
+	}
+	if m.Result != nil {
+		w.contentDescriptorOrRef(path+"/result", *m.Result)
+	}
+	if m.Errors != nil {
+		for i, e := range *m.Errors {
+			w.ref(path+"/errors/"+strconv.Itoa(i), "errors", e.IsRef(), e.Ref())
+		}
+	}
+	if m.Links != nil {
+		for i, link := range *m.Links {
+			w.ref(path+"/links/"+strconv.Itoa(i), "links", link.IsRef(), link.Ref())
+		}
+	}
+	if m.Examples != nil {
+		for i, pairing := range *m.Examples {
+			path := path + "/examples/" + strconv.Itoa(i)
+			if w.ref(path, "examplePairings", pairing.IsRef(), pairing.Ref()) {
+				w.examplePairing(path, pairing.Value())
+			}
+		}
+	}
+}
+
+func (w walker) examplePairing(path string, p *v1_4.ExamplePairingObject) {
+	if p.Params != nil {
+		for i, example := range *p.Params {
+			w.ref(path+"/params/"+strconv.Itoa(i), "examples", example.IsRef(), example.Ref())
+		}
+	}
+	if p.Result != nil {
+		w.ref(path+"/result", "examples", p.Result.IsRef(), p.Result.Ref())
+	}
+}
+
+func (w walker) contentDescriptorOrRef(path string, o v1_4.OrRef[v1_4.ContentDescriptorObject]) {
+	if w.ref(path, "contentDescriptors", o.IsRef(), o.Ref()) {
+		w.contentDescriptor(path, o.Value())
+	}
+}
+
+func (w walker) contentDescriptor(path string, c *v1_4.ContentDescriptorObject) {
+	if c != nil && c.Schema != nil {
+		schema := v1_4.JSONSchema(*c.Schema)
+		w.schema(path+"/schema", w.base, &schema)
+	}
+}
+
+// ref reports the reference of an OrRef union, returning true when it holds a value instead.
+func (w walker) ref(path, kind string, isRef bool, ref *v1_4.ReferenceObject) bool {
+	if isRef {
+		w.fn(path, w.base, deref.String(ref.Ref), kind)
+	}
+	return !isRef
+}
+
+func (w walker) schema(path string, base *url.URL, s *v1_4.JSONSchema) {
+	if s == nil || s.JSONSchemaObject == nil {
+		return
+	}
+	base = schemaBase(base, s.JSONSchemaObject)
+	if s.JSONSchemaObject.Ref != nil {
+		w.fn(path, base, string(*s.JSONSchemaObject.Ref), "schemas")
+	}
+	eachSubschema(s.JSONSchemaObject, func(tokens []string, child *v1_4.JSONSchema) {
+		childPath := path
+		for _, token := range tokens {
+			childPath += "/" + source.EscapeToken(token)
+		}
+		w.schema(childPath, base, child)
+	})
+}
+
+// schemaBase returns the base URI of the references within o.
+func schemaBase(base *url.URL, o *v1_4.JSONSchemaObject) *url.URL {
+	if o.Id == nil {
+		return base
+	}
+	id, err := url.Parse(string(*o.Id))
+	if err != nil || !hasLocation(id) {
+		return base
+	}
+	return withoutFragment(base.ResolveReference(id))
+}
+
+// eachSubschema calls fn for every schema directly nested in o, along with the JSON
+// pointer tokens leading to it. Changes fn makes to a schema are kept, including for
+// schemas held in maps.
+func eachSubschema(o *v1_4.JSONSchemaObject, fn func(tokens []string, s *v1_4.JSONSchema)) {
+	for _, single := range []struct {
+		keyword string
+		schema  *v1_4.JSONSchema
+	}{
+		{"additionalItems", o.AdditionalItems},
+		{"contains", o.Contains},
+		{"additionalProperties", o.AdditionalProperties},
+		{"propertyNames", o.PropertyNames},
+		{"if", o.If},
+		{"then", o.Then},
+		{"else", o.Else},
+		{"not", o.Not},
+	} {
+		if single.schema != nil {
+			fn([]string{single.keyword}, single.schema)
+		}
+	}
+	if o.Items != nil {
+		if o.Items.JSONSchema != nil {
+			fn([]string{"items"}, o.Items.JSONSchema)
+		}
+		eachIndexed("items", o.Items.SchemaArray, fn)
+	}
+	for _, named := range []struct {
+		keyword string
+		schemas *map[string]v1_4.JSONSchema
+	}{
+		{"definitions", (*map[string]v1_4.JSONSchema)(o.Definitions)},
+		{"properties", (*map[string]v1_4.JSONSchema)(o.Properties)},
+		{"patternProperties", (*map[string]v1_4.JSONSchema)(o.PatternProperties)},
+	} {
+		if named.schemas == nil {
+			continue
+		}
+		for _, name := range sorted.Keys(*named.schemas) {
+			schema := (*named.schemas)[name]
+			fn([]string{named.keyword, name}, &schema)
+			(*named.schemas)[name] = schema
+		}
+	}
+	if o.Dependencies != nil {
+		for _, name := range sorted.Keys(*o.Dependencies) {
+			if set := (*o.Dependencies)[name]; set.JSONSchema != nil {
+				fn([]string{"dependencies", name}, set.JSONSchema)
+			}
+		}
+	}
+	eachIndexed("allOf", o.AllOf, fn)
+	eachIndexed("anyOf", o.AnyOf, fn)
+	eachIndexed("oneOf", o.OneOf, fn)
+}
+
+func eachIndexed(keyword string, a *v1_4.SchemaArray, fn func(tokens []string, s *v1_4.JSONSchema)) {
+	if a == nil {
+		return
+	}
+	for i := range *a {
+		fn([]string{keyword, strconv.Itoa(i)}, &(*a)[i])
+	}
+}
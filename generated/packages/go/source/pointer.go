@@ -0,0 +1,19 @@
+package source
+
+import "strings"
+
+var (
+	tokenEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	tokenUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// EscapeToken escapes a member name for use as a reference token of a JSON pointer,
+// as described by RFC 6901.
+func EscapeToken(token string) string {
+	return tokenEscaper.Replace(token)
+}
+
+// UnescapeToken turns a reference token of a JSON pointer back into the member name.
+func UnescapeToken(token string) string {
+	return tokenUnescaper.Replace(token)
+}
@@ -0,0 +1,291 @@
+// Package source maps the JSON pointers of a JSON document to positions in its text,
+// so that problems found in decoded documents can be reported by line and column. It
+// also escapes the reference tokens the pointers are built from.
+package source
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a place in a document's text. Line and Column start at 1, and Column
+// counts Unicode code points.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Error is an error about a value of a document, located in the document's text.
+type Error struct {
+	// Pointer is the JSON pointer of the value, empty for the root and for errors
+	// in the syntax of the document.
+	Pointer  string
+	Position Position
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Position, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Map holds the position of every value of a document by JSON pointer.
+type Map struct {
+	// lines holds the offset each line starts at.
+	lines   []int
+	data    []byte
+	entries map[string]entry
+}
+
+// entry locates a value along with the key naming it, for object members.
+type entry struct {
+	key, value int
+	hasKey     bool
+}
+
+// Index scans the JSON document data. Errors in its syntax are returned as *Error values.
+func Index(data []byte) (*Map, error) {
+	m := &Map{lines: []int{0}, data: data, entries: map[string]entry{}}
+	for i, c := range data {
+		if c == '\n' {
+			m.lines = append(m.lines, i+1)
+		}
+	}
+	s := &scanner{data: data, m: m}
+	s.space()
+	if err := s.value("", entry{}); err != nil {
+		return nil, err
+	}
+	if s.space(); s.pos < len(data) {
+		return nil, s.errorf("unexpected data after the document")
+	}
+	return m, nil
+}
+
+// Lookup returns the position of the value at pointer. For object members this is
+// the position of the member's key.
+func (m *Map) Lookup(pointer string) (Position, bool) {
+	e, ok := m.entries[pointer]
+	if !ok {
+		return Position{}, false
+	}
+	if e.hasKey {
+		return m.Position(e.key), true
+	}
+	return m.Position(e.value), true
+}
+
+// Value returns the position of the value at pointer itself, after any key.
+func (m *Map) Value(pointer string) (Position, bool) {
+	e, ok := m.entries[pointer]
+	if !ok {
+		return Position{}, false
+	}
+	return m.Position(e.value), true
+}
+
+// Nearest returns the position of the value at pointer, or of its closest ancestor
+// when the document has no such value, along with the pointer it found. Pointers
+// that do not start with "/" fall back to the root.
+func (m *Map) Nearest(pointer string) (Position, string) {
+	for {
+		if p, ok := m.Lookup(pointer); ok || pointer == "" {
+			return p, pointer
+		}
+		pointer = pointer[:max(strings.LastIndexByte(pointer, '/'), 0)]
+	}
+}
+
+// Locate returns err located at the value at pointer, or at its closest ancestor
+// when the document has no such value.
+func (m *Map) Locate(pointer string, err error) *Error {
+	p, _ := m.Nearest(pointer)
+	return &Error{Pointer: pointer, Position: p, Err: err}
+}
+
+// Pointers returns every JSON pointer of the document, in document order.
+func (m *Map) Pointers() []string {
+	pointers := make([]string, 0, len(m.entries))
+	for pointer := range m.entries {
+		pointers = append(pointers, pointer)
+	}
+	sort.Slice(pointers, func(i, j int) bool {
+		return m.entries[pointers[i]].value < m.entries[pointers[j]].value
+	})
+	return pointers
+}
+
+// Position returns the position of offset.
+func (m *Map) Position(offset int) Position {
+	if offset > len(m.data) {
+		offset = len(m.data)
+	}
+	line := sort.Search(len(m.lines), func(i int) bool { return m.lines[i] > offset }) - 1
+	start := m.lines[line]
+	return Position{Offset: offset, Line: line + 1, Column: utf8.RuneCount(m.data[start:offset]) + 1}
+}
+
+// scanner walks a JSON document, recording the position of each value.
+type scanner struct {
+	data []byte
+	pos  int
+	m    *Map
+}
+
+func (s *scanner) errorf(format string, args ...interface{}) error {
+	return &Error{Position: s.m.Position(s.pos), Err: fmt.Errorf(format, args...)}
+}
+
+func (s *scanner) space() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *scanner) value(pointer string, e entry) error {
+	if s.pos >= len(s.data) {
+		return s.errorf("unexpected end of the document")
+	}
+	e.value = s.pos
+	s.m.entries[pointer] = e
+	switch c := s.data[s.pos]; {
+	case c == '{':
+		return s.object(pointer)
+	case c == '[':
+		return s.array(pointer)
+	case c == '"':
+		_, err := s.string()
+		return err
+	case c == '-' || c >= '0' && c <= '9':
+		return s.number()
+	default:
+		for _, literal := range []string{"true", "false", "null"} {
+			if bytes.HasPrefix(s.data[s.pos:], []byte(literal)) {
+				s.pos += len(literal)
+				return nil
+			}
+		}
+		return s.errorf("unexpected character %q", c)
+	}
+}
+
+func (s *scanner) object(pointer string) error {
+	s.pos++
+	s.space()
+	if s.pos < len(s.data) && s.data[s.pos] == '}' {
+		s.pos++
+		return nil
+	}
+	for {
+		if s.pos >= len(s.data) || s.data[s.pos] != '"' {
+			return s.errorf("expected an object key")
+		}
+		key := s.pos
+		name, err := s.string()
+		if err != nil {
+			return err
+		}
+		s.space()
+		if s.pos >= len(s.data) || s.data[s.pos] != ':' {
+			return s.errorf("expected ':' after an object key")
+		}
+		s.pos++
+		s.space()
+		if err := s.value(pointer+"/"+EscapeToken(name), entry{key: key, hasKey: true}); err != nil {
+			return err
+		}
+		if done, err := s.next('}'); done || err != nil {
+			return err
+		}
+	}
+}
+
+func (s *scanner) array(pointer string) error {
+	s.pos++
+	s.space()
+	if s.pos < len(s.data) && s.data[s.pos] == ']' {
+		s.pos++
+		return nil
+	}
+	for i := 0; ; i++ {
+		if err := s.value(pointer+"/"+strconv.Itoa(i), entry{}); err != nil {
+			return err
+		}
+		if done, err := s.next(']'); done || err != nil {
+			return err
+		}
+	}
+}
+
+// next moves past the separator after a member or element, reporting whether it
+// closed the object or array.
+func (s *scanner) next(end byte) (bool, error) {
+	s.space()
+	if s.pos >= len(s.data) {
+		return false, s.errorf("unexpected end of the document")
+	}
+	switch s.data[s.pos] {
+	case ',':
+		s.pos++
+		s.space()
+		return false, nil
+	case end:
+		s.pos++
+		return true, nil
+	}
+	return false, s.errorf("expected ',' or %q", end)
+}
+
+func (s *scanner) string() (string, error) {
+	start := s.pos
+	s.pos++
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos += 2
+		case '"':
+			s.pos++
+			var value string
+			if err := json.Unmarshal(s.data[start:s.pos], &value); err != nil {
+				s.pos = start
+				return "", s.errorf("invalid string")
+			}
+			return value, nil
+		default:
+			s.pos++
+		}
+	}
+	s.pos = start
+	return "", s.errorf("unterminated string")
+}
+
+func (s *scanner) number() error {
+	start := s.pos
+	for s.pos < len(s.data) && strings.IndexByte("+-.0123456789eE", s.data[s.pos]) >= 0 {
+		s.pos++
+	}
+	if _, err := strconv.ParseFloat(string(s.data[start:s.pos]), 64); err != nil && !errors.Is(err, strconv.ErrRange) {
+		s.pos = start
+		return s.errorf("invalid number")
+	}
+	return nil
+}
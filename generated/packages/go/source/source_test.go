@@ -0,0 +1,123 @@
+package source
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const sourceDoc = `{
+  "a": {"b/c": [1, "é", {"d~e": null}]},
+  "f": true
+}`
+
+func index(t *testing.T) *Map {
+	t.Helper()
+	m, err := Index([]byte(sourceDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestLookup(t *testing.T) {
+	m := index(t)
+	for _, c := range []struct {
+		pointer    string
+		key, value string
+	}{
+		{"", "1:1", "1:1"},
+		{"/a", "2:3", "2:8"},
+		{"/a/b~1c", "2:9", "2:16"},
+		{"/a/b~1c/0", "2:17", "2:17"},
+		{"/a/b~1c/2", "2:25", "2:25"},
+		// Columns count code points, so "é" is one column wide.
+		{"/a/b~1c/2/d~0e", "2:26", "2:33"},
+		{"/f", "3:3", "3:8"},
+	} {
+		key, ok := m.Lookup(c.pointer)
+		if !ok || key.String() != c.key {
+			t.Errorf("Lookup(%q) = %v, %v, want %s", c.pointer, key, ok, c.key)
+		}
+		value, ok := m.Value(c.pointer)
+		if !ok || value.String() != c.value {
+			t.Errorf("Value(%q) = %v, %v, want %s", c.pointer, value, ok, c.value)
+		}
+	}
+	if _, ok := m.Lookup("/missing"); ok {
+		t.Error("Lookup(/missing) found a value")
+	}
+	if _, ok := m.Value("/missing"); ok {
+		t.Error("Value(/missing) found a value")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	m := index(t)
+	for _, c := range []struct {
+		pointer, found, position string
+	}{
+		{"/a/b~1c/1", "/a/b~1c/1", "2:20"},
+		{"/a/b~1c/7/x", "/a/b~1c", "2:9"},
+		{"/missing", "", "1:1"},
+		{"", "", "1:1"},
+		// Pointers that do not start with "/" have no ancestor but the root.
+		{"a", "", "1:1"},
+		{"a/b~1c", "", "1:1"},
+	} {
+		p, found := m.Nearest(c.pointer)
+		if found != c.found || p.String() != c.position {
+			t.Errorf("Nearest(%q) = %v, %q, want %s, %q", c.pointer, p, found, c.position, c.found)
+		}
+	}
+}
+
+func TestLocate(t *testing.T) {
+	cause := errors.New("cause")
+	err := index(t).Locate("/a/gone", cause)
+	if err.Pointer != "/a/gone" || err.Position.String() != "2:3" || !errors.Is(err, cause) {
+		t.Errorf("Locate = %+v", err)
+	}
+	if got, want := err.Error(), "2:3: cause"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestPointers(t *testing.T) {
+	want := []string{"", "/a", "/a/b~1c", "/a/b~1c/0", "/a/b~1c/1", "/a/b~1c/2", "/a/b~1c/2/d~0e", "/f"}
+	if got := index(t).Pointers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Pointers() = %q, want %q", got, want)
+	}
+}
+
+func TestIndexErrors(t *testing.T) {
+	for _, c := range []struct {
+		data, err string
+	}{
+		{``, "1:1: unexpected end of the document"},
+		{`{"a": 1,}`, "1:9: expected an object key"},
+		{"{\n  \"a\" 1}", "2:7: expected ':' after an object key"},
+		{`[1 2]`, `1:4: expected ',' or ']'`},
+		{`{"a": "b`, "1:7: unterminated string"},
+		{`[-]`, "1:2: invalid number"},
+		{`[nul]`, "1:2: unexpected character 'n'"},
+		{`{} {}`, "1:4: unexpected data after the document"},
+	} {
+		_, err := Index([]byte(c.data))
+		var located *Error
+		if !errors.As(err, &located) || err.Error() != c.err {
+			t.Errorf("Index(%q) = %v, want %s", c.data, err, c.err)
+		}
+	}
+}
+
+func TestEscapeToken(t *testing.T) {
+	for token, escaped := range map[string]string{"a": "a", "b/c": "b~1c", "d~e": "d~0e", "~1": "~01", "/~": "~1~0"} {
+		if got := EscapeToken(token); got != escaped {
+			t.Errorf("EscapeToken(%q) = %q, want %q", token, got, escaped)
+		}
+		if got := UnescapeToken(escaped); got != token {
+			t.Errorf("UnescapeToken(%q) = %q, want %q", escaped, got, token)
+		}
+	}
+}
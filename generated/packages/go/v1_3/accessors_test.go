@@ -0,0 +1,104 @@
+package v1_3
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const accessorsDoc = `{
+	"openrpc": "1.3.2",
+	"info": {"title": "Petstore", "description": "Pets.", "version": "1.0.0"},
+	"methods": [
+		{
+			"name": "get_pet",
+			"summary": "Gets a pet.",
+			"tags": [{"name": "pets"}, {"$ref": "#/components/tags/read"}],
+			"paramStructure": "by-name",
+			"params": [
+				{"$ref": "#/components/contentDescriptors/Verbose"},
+				{"name": "id", "required": true, "schema": {"title": "Id", "type": ["integer", "string"]}}
+			],
+			"result": {
+				"name": "pet",
+				"deprecated": true,
+				"schema": {"required": ["name"], "properties": {"name": {"type": "string"}, "any": true}}
+			},
+			"deprecated": true
+		},
+		{"$ref": "#/components/methods/list_pets"},
+		{"name": "notify", "params": [], "result": {"$ref": "#/components/contentDescriptors/Ack"}}
+	]
+}`
+
+func TestAccessors(t *testing.T) {
+	var doc OpenrpcDocument
+	if err := json.Unmarshal([]byte(accessorsDoc), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.GetOpenrpc() != "1.3.2" || doc.GetTitle() != "Petstore" || doc.GetDescription() != "Pets." || doc.GetVersion() != "1.0.0" {
+		t.Errorf("document = %q, %q, %q, %q", doc.GetOpenrpc(), doc.GetTitle(), doc.GetDescription(), doc.GetVersion())
+	}
+
+	methods := doc.GetMethods()
+	if len(methods) != 2 || methods[0].GetName() != "get_pet" || methods[1].GetName() != "notify" {
+		t.Fatalf("GetMethods() = %v, want the inline get_pet and notify", methods)
+	}
+	get, notify := methods[0], methods[1]
+	if get.GetSummary() != "Gets a pet." || get.GetParamStructure() != "by-name" || !get.IsDeprecated() {
+		t.Errorf("get_pet = %q, %q, %v", get.GetSummary(), get.GetParamStructure(), get.IsDeprecated())
+	}
+	if notify.GetParamStructure() != "either" || notify.IsDeprecated() {
+		t.Errorf("notify defaults = %q, %v", notify.GetParamStructure(), notify.IsDeprecated())
+	}
+	if tags := get.GetTags(); !reflect.DeepEqual(tags, []string{"pets"}) {
+		t.Errorf("GetTags() = %v", tags)
+	}
+	if notify.GetResult() != nil {
+		t.Errorf("notify result = %v, want nil for a Reference Object", notify.GetResult())
+	}
+
+	params := get.GetParams()
+	if len(params) != 1 || params[0].GetName() != "id" || !params[0].IsRequired() || params[0].IsDeprecated() {
+		t.Fatalf("GetParams() = %v, want the inline id", params)
+	}
+	id := params[0].GetSchema()
+	if id.GetTitle() != "Id" || !reflect.DeepEqual(id.GetTypes(), []string{"integer", "string"}) {
+		t.Errorf("id schema = %q, %v", id.GetTitle(), id.GetTypes())
+	}
+
+	result := get.GetResult()
+	if result == nil || result.GetName() != "pet" || !result.IsDeprecated() || result.IsRequired() {
+		t.Fatalf("GetResult() = %v", result)
+	}
+	schema := result.GetSchema()
+	if !reflect.DeepEqual(schema.GetRequired(), []string{"name"}) {
+		t.Errorf("GetRequired() = %v", schema.GetRequired())
+	}
+	properties := schema.GetProperties()
+	if types := properties["name"].GetTypes(); !reflect.DeepEqual(types, []string{"string"}) {
+		t.Errorf("name types = %v", types)
+	}
+	if value, ok := properties["any"].Boolean(); !value || !ok {
+		t.Errorf("any = %v, %v, want a true boolean schema", value, ok)
+	}
+	if _, ok := schema.Boolean(); ok {
+		t.Error("object schema reported as boolean")
+	}
+}
+
+func TestAccessorsOfEmptyValues(t *testing.T) {
+	var doc OpenrpcDocument
+	if doc.GetTitle() != "" || doc.GetVersion() != "" || doc.GetMethods() != nil {
+		t.Error("empty document has values")
+	}
+	var c ContentDescriptorObject
+	if c.GetSchema() != nil {
+		t.Error("content descriptor without schema has one")
+	}
+	b := JSONSchemaBoolean(false)
+	s := JSONSchema{JSONSchemaBoolean: &b}
+	if s.GetRef() != "" || s.GetTitle() != "" || s.GetTypes() != nil || s.GetProperties() != nil {
+		t.Error("boolean schema has keywords")
+	}
+}
@@ -0,0 +1,30 @@
+package v1_3
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Known reports whether o is one of the versions in KnownOpenrpc.
+func (o Openrpc) Known() bool {
+	for _, known := range KnownOpenrpc {
+		if o == known {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Only the versions in KnownOpenrpc are accepted, mirroring the enum of the meta-schema.
+func (o *Openrpc) UnmarshalJSON(bytes []byte) error {
+	var s string
+	if err := json.Unmarshal(bytes, &s); err != nil {
+		return err
+	}
+	if !Openrpc(s).Known() {
+		return fmt.Errorf("unsupported OpenRPC version %q", s)
+	}
+	*o = Openrpc(s)
+	return nil
+}
@@ -0,0 +1,22 @@
+package v1_3
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenrpcUnmarshal(t *testing.T) {
+	for _, known := range KnownOpenrpc {
+		data, _ := json.Marshal(known)
+		var o Openrpc
+		if err := json.Unmarshal(data, &o); err != nil || o != known {
+			t.Errorf("%s: got %q, %v", known, o, err)
+		}
+	}
+	for _, invalid := range []string{`"1.4.0"`, `"1.2.7"`, `"1.3.2+build"`, `"1.3"`, `132`} {
+		var o Openrpc
+		if err := json.Unmarshal([]byte(invalid), &o); err == nil {
+			t.Errorf("%s: accepted", invalid)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package v1_3
 
 import "encoding/json"
 import "errors"
+import "math"
+import "sort"
+import "strings"
 type Openrpc string
 const (
 	OpenrpcEnum0 Openrpc = "1.3.2"
@@ -31,6 +34,35 @@ const (
 	OpenrpcEnum24 Openrpc = "1.0.0-rc0"
 	OpenrpcEnum25 Openrpc = "1.0.0-rc1"
 )
+// KnownOpenrpc lists the values of Openrpc enumerated by the meta-schema, in its order.
+var KnownOpenrpc = []Openrpc{
+	OpenrpcEnum0,
+	OpenrpcEnum1,
+	OpenrpcEnum2,
+	OpenrpcEnum3,
+	OpenrpcEnum4,
+	OpenrpcEnum5,
+	OpenrpcEnum6,
+	OpenrpcEnum7,
+	OpenrpcEnum8,
+	OpenrpcEnum9,
+	OpenrpcEnum10,
+	OpenrpcEnum11,
+	OpenrpcEnum12,
+	OpenrpcEnum13,
+	OpenrpcEnum14,
+	OpenrpcEnum15,
+	OpenrpcEnum16,
+	OpenrpcEnum17,
+	OpenrpcEnum18,
+	OpenrpcEnum19,
+	OpenrpcEnum20,
+	OpenrpcEnum21,
+	OpenrpcEnum22,
+	OpenrpcEnum23,
+	OpenrpcEnum24,
+	OpenrpcEnum25,
+}
 type InfoObjectProperties string
 type InfoObjectDescription string
 type InfoObjectTermsOfService string
@@ -40,23 +72,89 @@ type ContactObjectEmail string
 type ContactObjectUrl string
 type SpecificationExtension interface{}
 type ContactObject struct {
-	Name  *ContactObjectName  `json:"name,omitempty"`
-	Email *ContactObjectEmail `json:"email,omitempty"`
-	Url   *ContactObjectUrl   `json:"url,omitempty"`
+	Name       *ContactObjectName         `json:"name,omitempty"`
+	Email      *ContactObjectEmail        `json:"email,omitempty"`
+	Url        *ContactObjectUrl          `json:"url,omitempty"`
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ContactObject) UnmarshalJSON(bytes []byte) error {
+	type plain ContactObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ContactObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ContactObject) MarshalJSON() ([]byte, error) {
+	type plain ContactObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type LicenseObjectName string
 type LicenseObjectUrl string
 type LicenseObject struct {
-	Name *LicenseObjectName `json:"name,omitempty"`
-	Url  *LicenseObjectUrl  `json:"url,omitempty"`
+	Name       *LicenseObjectName         `json:"name,omitempty"`
+	Url        *LicenseObjectUrl          `json:"url,omitempty"`
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LicenseObject) UnmarshalJSON(bytes []byte) error {
+	type plain LicenseObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = LicenseObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LicenseObject) MarshalJSON() ([]byte, error) {
+	type plain LicenseObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type InfoObject struct {
-	Title          *InfoObjectProperties     `json:"title"`
-	Description    *InfoObjectDescription    `json:"description,omitempty"`
-	TermsOfService *InfoObjectTermsOfService `json:"termsOfService,omitempty"`
-	Version        *InfoObjectVersion        `json:"version"`
-	Contact        *ContactObject            `json:"contact,omitempty"`
-	License        *LicenseObject            `json:"license,omitempty"`
+	Title          *InfoObjectProperties      `json:"title"`
+	Description    *InfoObjectDescription     `json:"description,omitempty"`
+	TermsOfService *InfoObjectTermsOfService  `json:"termsOfService,omitempty"`
+	Version        *InfoObjectVersion         `json:"version"`
+	Contact        *ContactObject             `json:"contact,omitempty"`
+	License        *LicenseObject             `json:"license,omitempty"`
+	Extensions     map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *InfoObject) UnmarshalJSON(bytes []byte) error {
+	type plain InfoObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = InfoObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o InfoObject) MarshalJSON() ([]byte, error) {
+	type plain InfoObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type ExternalDocumentationObjectDescription string
 type ExternalDocumentationObjectUrl string
@@ -64,6 +162,28 @@ type ExternalDocumentationObjectUrl string
 type ExternalDocumentationObject struct {
 	Description *ExternalDocumentationObjectDescription `json:"description,omitempty"`
 	Url         *ExternalDocumentationObjectUrl         `json:"url"`
+	Extensions  map[string]json.RawMessage              `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ExternalDocumentationObject) UnmarshalJSON(bytes []byte) error {
+	type plain ExternalDocumentationObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ExternalDocumentationObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ExternalDocumentationObject) MarshalJSON() ([]byte, error) {
+	type plain ExternalDocumentationObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type ServerObjectUrl string
 type ServerObjectName string
@@ -78,13 +198,35 @@ type ServerObjectVariable struct {
 	Description *ServerObjectVariableDescription `json:"description,omitempty"`
 	Enum        *ServerObjectVariableEnum        `json:"enum,omitempty"`
 }
-type ServerObjectVariables map[string]interface{}
+type ServerObjectVariables map[string]ServerObjectVariable
 type ServerObject struct {
-	Url         *ServerObjectUrl         `json:"url"`
-	Name        *ServerObjectName        `json:"name,omitempty"`
-	Description *ServerObjectDescription `json:"description,omitempty"`
-	Summary     *ServerObjectSummary     `json:"summary,omitempty"`
-	Variables   *ServerObjectVariables   `json:"variables,omitempty"`
+	Url         *ServerObjectUrl           `json:"url"`
+	Name        *ServerObjectName          `json:"name,omitempty"`
+	Description *ServerObjectDescription   `json:"description,omitempty"`
+	Summary     *ServerObjectSummary       `json:"summary,omitempty"`
+	Variables   *ServerObjectVariables     `json:"variables,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ServerObject) UnmarshalJSON(bytes []byte) error {
+	type plain ServerObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ServerObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ServerObject) MarshalJSON() ([]byte, error) {
+	type plain ServerObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type AlwaysFalse interface{}
 type Servers []ServerObject
@@ -100,41 +242,34 @@ type TagObject struct {
 	Name         *TagObjectName               `json:"name"`
 	Description  *TagObjectDescription        `json:"description,omitempty"`
 	ExternalDocs *ExternalDocumentationObject `json:"externalDocs,omitempty"`
+	Extensions   map[string]json.RawMessage   `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *TagObject) UnmarshalJSON(bytes []byte) error {
+	type plain TagObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = TagObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o TagObject) MarshalJSON() ([]byte, error) {
+	type plain TagObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type Ref string
 type ReferenceObject struct {
 	Ref *Ref `json:"$ref"`
 }
-type TagOrReference struct {
-	TagObject       *TagObject
-	ReferenceObject *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *TagOrReference) UnmarshalJSON(bytes []byte) error {
-	var myTagObject TagObject
-	if err := json.Unmarshal(bytes, &myTagObject); err == nil {
-		o.TagObject = &myTagObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o TagOrReference) MarshalJSON() ([]byte, error) {
-	if o.TagObject != nil {
-		return json.Marshal(o.TagObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type TagOrReference = OrRef[TagObject]
 type MethodObjectTags []TagOrReference
 // Format the server expects the params. Defaults to 'either'.
 //
@@ -164,7 +299,27 @@ type ExclusiveMaximum float64
 type Minimum float64
 type ExclusiveMinimum float64
 type NonNegativeInteger int64
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *NonNegativeInteger) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
+	}
+	*o = NonNegativeInteger(i)
+	return nil
+}
 type NonNegativeIntegerDefaultZero int64
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *NonNegativeIntegerDefaultZero) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
+	}
+	*o = NonNegativeIntegerDefaultZero(i)
+	return nil
+}
 type Pattern string
 type SchemaArray []JSONSchema
 //
@@ -175,32 +330,33 @@ type Items struct {
 	JSONSchema  *JSONSchema
 	SchemaArray *SchemaArray
 }
-func (a *Items) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var myJSONSchema JSONSchema
-	if err := json.Unmarshal(bytes, &myJSONSchema); err == nil {
-		ok = true
-		a.JSONSchema = &myJSONSchema
-	}
-	var mySchemaArray SchemaArray
-	if err := json.Unmarshal(bytes, &mySchemaArray); err == nil {
-		ok = true
-		a.SchemaArray = &mySchemaArray
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into SchemaArray and any other value into JSONSchema, so exactly one variant is set.
+func (o *Items) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var mySchemaArray SchemaArray
+		if err := json.Unmarshal(bytes, &mySchemaArray); err != nil {
+			return err
+		}
+		*o = Items{SchemaArray: &mySchemaArray}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var myJSONSchema JSONSchema
+	if err := json.Unmarshal(bytes, &myJSONSchema); err != nil {
+		return err
+	}
+	*o = Items{JSONSchema: &myJSONSchema}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o Items) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.JSONSchema != nil {
-		out = append(out, o.JSONSchema)
+		return json.Marshal(o.JSONSchema)
 	}
 	if o.SchemaArray != nil {
-		out = append(out, o.SchemaArray)
+		return json.Marshal(o.SchemaArray)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
 type UniqueItems bool
 type StringDoaGddGA string
@@ -213,50 +369,51 @@ type StringArray []StringDoaGddGA
 // --- Default ---
 //
 // {}
-type Definitions map[string]interface{}
+type Definitions map[string]JSONSchema
 //
 // --- Default ---
 //
 // {}
-type Properties map[string]interface{}
+type Properties map[string]JSONSchema
 type PropertyNames interface{}
 //
 // --- Default ---
 //
 // {}
-type PatternProperties map[string]interface{}
+type PatternProperties map[string]JSONSchema
 type DependenciesSet struct {
 	JSONSchema  *JSONSchema
 	StringArray *StringArray
 }
-func (a *DependenciesSet) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var myJSONSchema JSONSchema
-	if err := json.Unmarshal(bytes, &myJSONSchema); err == nil {
-		ok = true
-		a.JSONSchema = &myJSONSchema
-	}
-	var myStringArray StringArray
-	if err := json.Unmarshal(bytes, &myStringArray); err == nil {
-		ok = true
-		a.StringArray = &myStringArray
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into StringArray and any other value into JSONSchema, so exactly one variant is set.
+func (o *DependenciesSet) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var myStringArray StringArray
+		if err := json.Unmarshal(bytes, &myStringArray); err != nil {
+			return err
+		}
+		*o = DependenciesSet{StringArray: &myStringArray}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var myJSONSchema JSONSchema
+	if err := json.Unmarshal(bytes, &myJSONSchema); err != nil {
+		return err
+	}
+	*o = DependenciesSet{JSONSchema: &myJSONSchema}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o DependenciesSet) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.JSONSchema != nil {
-		out = append(out, o.JSONSchema)
+		return json.Marshal(o.JSONSchema)
 	}
 	if o.StringArray != nil {
-		out = append(out, o.StringArray)
+		return json.Marshal(o.StringArray)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
-type Dependencies map[string]interface{}
+type Dependencies map[string]DependenciesSet
 type Enum []AlwaysTrue
 type SimpleTypes string
 const (
@@ -273,32 +430,33 @@ type Type struct {
 	SimpleTypes        *SimpleTypes
 	ArrayOfSimpleTypes *ArrayOfSimpleTypes
 }
-func (a *Type) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var mySimpleTypes SimpleTypes
-	if err := json.Unmarshal(bytes, &mySimpleTypes); err == nil {
-		ok = true
-		a.SimpleTypes = &mySimpleTypes
-	}
-	var myArrayOfSimpleTypes ArrayOfSimpleTypes
-	if err := json.Unmarshal(bytes, &myArrayOfSimpleTypes); err == nil {
-		ok = true
-		a.ArrayOfSimpleTypes = &myArrayOfSimpleTypes
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into ArrayOfSimpleTypes and any other value into SimpleTypes, so exactly one variant is set.
+func (o *Type) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var myArrayOfSimpleTypes ArrayOfSimpleTypes
+		if err := json.Unmarshal(bytes, &myArrayOfSimpleTypes); err != nil {
+			return err
+		}
+		*o = Type{ArrayOfSimpleTypes: &myArrayOfSimpleTypes}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var mySimpleTypes SimpleTypes
+	if err := json.Unmarshal(bytes, &mySimpleTypes); err != nil {
+		return err
+	}
+	*o = Type{SimpleTypes: &mySimpleTypes}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o Type) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.SimpleTypes != nil {
-		out = append(out, o.SimpleTypes)
+		return json.Marshal(o.SimpleTypes)
 	}
 	if o.ArrayOfSimpleTypes != nil {
-		out = append(out, o.ArrayOfSimpleTypes)
+		return json.Marshal(o.ArrayOfSimpleTypes)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
 type Format string
 type ContentMediaType string
@@ -336,7 +494,7 @@ type JSONSchemaObject struct {
 	PatternProperties    *PatternProperties             `json:"patternProperties,omitempty"`
 	Dependencies         *Dependencies                  `json:"dependencies,omitempty"`
 	PropertyNames        *JSONSchema                    `json:"propertyNames,omitempty"`
-	Const                *AlwaysTrue                    `json:"const,omitempty"`
+	Const                json.RawMessage                `json:"const,omitempty"`
 	Enum                 *Enum                          `json:"enum,omitempty"`
 	Type                 *Type                          `json:"type,omitempty"`
 	Format               *Format                        `json:"format,omitempty"`
@@ -395,70 +553,44 @@ type ContentDescriptorObject struct {
 	Schema      *JSONSchema                         `json:"schema"`
 	Required    *ContentDescriptorObjectRequired    `json:"required,omitempty"`
 	Deprecated  *ContentDescriptorObjectDeprecated  `json:"deprecated,omitempty"`
-}
-type ContentDescriptorOrReference struct {
-	ContentDescriptorObject *ContentDescriptorObject
-	ReferenceObject         *ReferenceObject
+	Extensions  map[string]json.RawMessage          `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ContentDescriptorOrReference) UnmarshalJSON(bytes []byte) error {
-	var myContentDescriptorObject ContentDescriptorObject
-	if err := json.Unmarshal(bytes, &myContentDescriptorObject); err == nil {
-		o.ContentDescriptorObject = &myContentDescriptorObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ContentDescriptorOrReference) MarshalJSON() ([]byte, error) {
-	if o.ContentDescriptorObject != nil {
-		return json.Marshal(o.ContentDescriptorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ContentDescriptorObject) UnmarshalJSON(bytes []byte) error {
+	type plain ContentDescriptorObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ContentDescriptorObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ContentDescriptorObject) MarshalJSON() ([]byte, error) {
+	type plain ContentDescriptorObject
+	return marshalExtensions(plain(o), o.Extensions)
+}
+type ContentDescriptorOrReference = OrRef[ContentDescriptorObject]
 type MethodObjectParams []ContentDescriptorOrReference
-type MethodObjectResult struct {
-	ContentDescriptorObject *ContentDescriptorObject
-	ReferenceObject         *ReferenceObject
-}
+type MethodObjectResult = OrRef[ContentDescriptorObject]
+// A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.
+type ErrorObjectCode int64
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *MethodObjectResult) UnmarshalJSON(bytes []byte) error {
-	var myContentDescriptorObject ContentDescriptorObject
-	if err := json.Unmarshal(bytes, &myContentDescriptorObject); err == nil {
-		o.ContentDescriptorObject = &myContentDescriptorObject
-		return nil
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *ErrorObjectCode) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = ErrorObjectCode(i)
+	return nil
 }
-func (o MethodObjectResult) MarshalJSON() ([]byte, error) {
-	if o.ContentDescriptorObject != nil {
-		return json.Marshal(o.ContentDescriptorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
-// A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.
-type ErrorObjectCode int64
 // A String providing a short description of the error. The message SHOULD be limited to a concise single sentence.
 type ErrorObjectMessage string
 // A Primitive or Structured value that contains additional information about the error. This may be omitted. The value of this member is defined by the Server (e.g. detailed error information, nested errors etc.).
@@ -469,88 +601,76 @@ type ErrorObject struct {
 	Message *ErrorObjectMessage `json:"message"`
 	Data    *ErrorObjectData    `json:"data,omitempty"`
 }
-type ErrorOrReference struct {
-	ErrorObject     *ErrorObject
-	ReferenceObject *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ErrorOrReference) UnmarshalJSON(bytes []byte) error {
-	var myErrorObject ErrorObject
-	if err := json.Unmarshal(bytes, &myErrorObject); err == nil {
-		o.ErrorObject = &myErrorObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ErrorOrReference) MarshalJSON() ([]byte, error) {
-	if o.ErrorObject != nil {
-		return json.Marshal(o.ErrorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ErrorOrReference = OrRef[ErrorObject]
 // Defines an application level error.
 type MethodObjectErrors []ErrorOrReference
 type LinkObjectName string
 type LinkObjectSummary string
 type LinkObjectMethod string
 type LinkObjectDescription string
-type LinkObjectParams interface{}
+type LinkObjectParams map[string]RuntimeExpression
+// A constant or a [runtime expression](#runtime-expression) evaluated and passed to the linked method.
+type RuntimeExpression interface{}
 type LinkObjectServer struct {
-	Url         *ServerObjectUrl         `json:"url"`
-	Name        *ServerObjectName        `json:"name,omitempty"`
-	Description *ServerObjectDescription `json:"description,omitempty"`
-	Summary     *ServerObjectSummary     `json:"summary,omitempty"`
-	Variables   *ServerObjectVariables   `json:"variables,omitempty"`
-}
-type LinkObject struct {
-	Name        *LinkObjectName        `json:"name,omitempty"`
-	Summary     *LinkObjectSummary     `json:"summary,omitempty"`
-	Method      *LinkObjectMethod      `json:"method,omitempty"`
-	Description *LinkObjectDescription `json:"description,omitempty"`
-	Params      *LinkObjectParams      `json:"params,omitempty"`
-	Server      *LinkObjectServer      `json:"server,omitempty"`
-}
-type LinkOrReference struct {
-	LinkObject      *LinkObject
-	ReferenceObject *ReferenceObject
+	Url         *ServerObjectUrl           `json:"url"`
+	Name        *ServerObjectName          `json:"name,omitempty"`
+	Description *ServerObjectDescription   `json:"description,omitempty"`
+	Summary     *ServerObjectSummary       `json:"summary,omitempty"`
+	Variables   *ServerObjectVariables     `json:"variables,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *LinkOrReference) UnmarshalJSON(bytes []byte) error {
-	var myLinkObject LinkObject
-	if err := json.Unmarshal(bytes, &myLinkObject); err == nil {
-		o.LinkObject = &myLinkObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LinkObjectServer) UnmarshalJSON(bytes []byte) error {
+	type plain LinkObjectServer
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = LinkObjectServer(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LinkObjectServer) MarshalJSON() ([]byte, error) {
+	type plain LinkObjectServer
+	return marshalExtensions(plain(o), o.Extensions)
 }
-func (o LinkOrReference) MarshalJSON() ([]byte, error) {
-	if o.LinkObject != nil {
-		return json.Marshal(o.LinkObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+type LinkObject struct {
+	Name        *LinkObjectName            `json:"name,omitempty"`
+	Summary     *LinkObjectSummary         `json:"summary,omitempty"`
+	Method      *LinkObjectMethod          `json:"method,omitempty"`
+	Description *LinkObjectDescription     `json:"description,omitempty"`
+	Params      *LinkObjectParams          `json:"params,omitempty"`
+	Server      *LinkObjectServer          `json:"server,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
 }
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LinkObject) UnmarshalJSON(bytes []byte) error {
+	type plain LinkObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = LinkObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LinkObject) MarshalJSON() ([]byte, error) {
+	type plain LinkObject
+	return marshalExtensions(plain(o), o.Extensions)
+}
+type LinkOrReference = OrRef[LinkObject]
 type MethodObjectLinks []LinkOrReference
 type ExamplePairingObjectName string
 type ExamplePairingObjectDescription string
@@ -559,108 +679,43 @@ type ExampleObjectValue interface{}
 type ExampleObjectDescription string
 type ExampleObjectName string
 type ExampleObject struct {
-	Summary     *ExampleObjectSummary     `json:"summary,omitempty"`
-	Value       *ExampleObjectValue       `json:"value"`
-	Description *ExampleObjectDescription `json:"description,omitempty"`
-	Name        *ExampleObjectName        `json:"name"`
-}
-type ExampleOrReference struct {
-	ExampleObject   *ExampleObject
-	ReferenceObject *ReferenceObject
+	Summary     *ExampleObjectSummary      `json:"summary,omitempty"`
+	Value       *ExampleObjectValue        `json:"value"`
+	Description *ExampleObjectDescription  `json:"description,omitempty"`
+	Name        *ExampleObjectName         `json:"name"`
+	Extensions  map[string]json.RawMessage `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExampleOrReference) UnmarshalJSON(bytes []byte) error {
-	var myExampleObject ExampleObject
-	if err := json.Unmarshal(bytes, &myExampleObject); err == nil {
-		o.ExampleObject = &myExampleObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ExampleOrReference) MarshalJSON() ([]byte, error) {
-	if o.ExampleObject != nil {
-		return json.Marshal(o.ExampleObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ExampleObject) UnmarshalJSON(bytes []byte) error {
+	type plain ExampleObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ExampleObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ExampleObject) MarshalJSON() ([]byte, error) {
+	type plain ExampleObject
+	return marshalExtensions(plain(o), o.Extensions)
+}
+type ExampleOrReference = OrRef[ExampleObject]
 type ExamplePairingObjectParams []ExampleOrReference
-type ExamplePairingObjectResult struct {
-	ExampleObject   *ExampleObject
-	ReferenceObject *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExamplePairingObjectResult) UnmarshalJSON(bytes []byte) error {
-	var myExampleObject ExampleObject
-	if err := json.Unmarshal(bytes, &myExampleObject); err == nil {
-		o.ExampleObject = &myExampleObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ExamplePairingObjectResult) MarshalJSON() ([]byte, error) {
-	if o.ExampleObject != nil {
-		return json.Marshal(o.ExampleObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ExamplePairingObjectResult = OrRef[ExampleObject]
 type ExamplePairingObject struct {
 	Name        *ExamplePairingObjectName        `json:"name"`
 	Description *ExamplePairingObjectDescription `json:"description,omitempty"`
 	Params      *ExamplePairingObjectParams      `json:"params"`
 	Result      *ExamplePairingObjectResult      `json:"result,omitempty"`
 }
-type ExamplePairingOrReference struct {
-	ExamplePairingObject *ExamplePairingObject
-	ReferenceObject      *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExamplePairingOrReference) UnmarshalJSON(bytes []byte) error {
-	var myExamplePairingObject ExamplePairingObject
-	if err := json.Unmarshal(bytes, &myExamplePairingObject); err == nil {
-		o.ExamplePairingObject = &myExamplePairingObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ExamplePairingOrReference) MarshalJSON() ([]byte, error) {
-	if o.ExamplePairingObject != nil {
-		return json.Marshal(o.ExamplePairingObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ExamplePairingOrReference = OrRef[ExamplePairingObject]
 type MethodObjectExamples []ExamplePairingOrReference
 type MethodObjectDeprecated bool
 type MethodObject struct {
@@ -677,45 +732,38 @@ type MethodObject struct {
 	Examples       *MethodObjectExamples        `json:"examples,omitempty"`
 	Deprecated     *MethodObjectDeprecated      `json:"deprecated,omitempty"`
 	ExternalDocs   *ExternalDocumentationObject `json:"externalDocs,omitempty"`
-}
-type MethodOrReference struct {
-	MethodObject    *MethodObject
-	ReferenceObject *ReferenceObject
+	Extensions     map[string]json.RawMessage   `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *MethodOrReference) UnmarshalJSON(bytes []byte) error {
-	var myMethodObject MethodObject
-	if err := json.Unmarshal(bytes, &myMethodObject); err == nil {
-		o.MethodObject = &myMethodObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o MethodOrReference) MarshalJSON() ([]byte, error) {
-	if o.MethodObject != nil {
-		return json.Marshal(o.MethodObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *MethodObject) UnmarshalJSON(bytes []byte) error {
+	type plain MethodObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = MethodObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o MethodObject) MarshalJSON() ([]byte, error) {
+	type plain MethodObject
+	return marshalExtensions(plain(o), o.Extensions)
+}
+type MethodOrReference = OrRef[MethodObject]
 type Methods []MethodOrReference
-type SchemaComponents map[string]interface{}
-type LinkComponents map[string]interface{}
-type ErrorComponents map[string]interface{}
-type ExampleComponents map[string]interface{}
-type ExamplePairingComponents map[string]interface{}
-type ContentDescriptorComponents map[string]interface{}
-type TagComponents map[string]interface{}
+type SchemaComponents map[string]JSONSchema
+type LinkComponents map[string]LinkObject
+type ErrorComponents map[string]ErrorObject
+type ExampleComponents map[string]ExampleObject
+type ExamplePairingComponents map[string]ExamplePairingObject
+type ContentDescriptorComponents map[string]ContentDescriptorObject
+type TagComponents map[string]TagObject
 type Components struct {
 	Schemas            *SchemaComponents            `json:"schemas,omitempty"`
 	Links              *LinkComponents              `json:"links,omitempty"`
@@ -739,6 +787,198 @@ type OpenrpcDocument struct {
 	Methods      *Methods                     `json:"methods"`
 	Components   *Components                  `json:"components,omitempty"`
 	Schema       *MetaSchema                  `json:"$schema,omitempty"`
+	Extensions   map[string]json.RawMessage   `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *OpenrpcDocument) UnmarshalJSON(bytes []byte) error {
+	type plain OpenrpcDocument
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = OpenrpcDocument(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o OpenrpcDocument) MarshalJSON() ([]byte, error) {
+	type plain OpenrpcDocument
+	return marshalExtensions(plain(o), o.Extensions)
+}
+// OrRef holds either a T or a Reference Object pointing at one.
+// When unmarshalling, the variant is chosen by the presence of "$ref": a Reference Object
+// carries it and nothing else, while a T never does and has at least one of its fields.
+// Any other input is rejected.
+type OrRef[T any] struct {
+	value     *T
+	reference *ReferenceObject
+}
+// NewValue returns an OrRef holding v.
+func NewValue[T any](v T) OrRef[T] {
+	return OrRef[T]{value: &v}
+}
+// NewRef returns an OrRef holding a Reference Object to ref.
+func NewRef[T any](ref string) OrRef[T] {
+	r := Ref(ref)
+	return OrRef[T]{reference: &ReferenceObject{Ref: &r}}
+}
+// IsRef reports whether o holds a Reference Object.
+func (o OrRef[T]) IsRef() bool {
+	return o.reference != nil
+}
+// Value returns the T held by o, or nil when o holds a Reference Object.
+func (o OrRef[T]) Value() *T {
+	return o.value
+}
+// Ref returns the Reference Object held by o, or nil when o holds a T.
+func (o OrRef[T]) Ref() *ReferenceObject {
+	return o.reference
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (o *OrRef[T]) UnmarshalJSON(bytes []byte) error {
+	isReference, err := isReferenceObject(bytes, jsonFields(new(T)))
+	if err != nil {
+		return err
+	}
+	if isReference {
+		var myReferenceObject ReferenceObject
+		if err := json.Unmarshal(bytes, &myReferenceObject); err != nil {
+			return err
+		}
+		*o = OrRef[T]{reference: &myReferenceObject}
+		return nil
+	}
+	var myValue T
+	if err := json.Unmarshal(bytes, &myValue); err != nil {
+		return err
+	}
+	*o = OrRef[T]{value: &myValue}
+	return nil
+}
+func (o OrRef[T]) MarshalJSON() ([]byte, error) {
+	if o.value != nil {
+		return json.Marshal(o.value)
+	}
+	if o.reference != nil {
+		return json.Marshal(o.reference)
+	}
+	return nil, errors.New("failed to marshal any one of the object properties")
+}
+// jsonFields returns the JSON names of the fields of the T an OrRef holds, or nil when T is
+// not one of the generated objects.
+func jsonFields(v any) []string {
+	switch v.(type) {
+	case *TagObject:
+		return []string{"name", "description", "externalDocs"}
+	case *ContentDescriptorObject:
+		return []string{"name", "description", "summary", "schema", "required", "deprecated"}
+	case *ErrorObject:
+		return []string{"code", "message", "data"}
+	case *LinkObject:
+		return []string{"name", "summary", "method", "description", "params", "server"}
+	case *ExampleObject:
+		return []string{"summary", "value", "description", "name"}
+	case *ExamplePairingObject:
+		return []string{"name", "description", "params", "result"}
+	case *MethodObject:
+		return []string{"name", "description", "summary", "servers", "tags", "paramStructure", "params", "result", "errors", "links", "examples", "deprecated", "externalDocs"}
+	}
+	return nil
+}
+// isReferenceObject reports whether a JSON object is a Reference Object, i.e. has a "$ref" field.
+// Objects mixing "$ref" with other fields are ambiguous and rejected, as are objects without any
+// of the given field names of the other variant and anything but an object. Nil names accept
+// any object without "$ref".
+func isReferenceObject(bytes []byte, names []string) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil || fields == nil {
+		return false, errors.New("expected a JSON object or a Reference Object")
+	}
+	if _, ok := fields["$ref"]; !ok {
+		if names == nil {
+			return false, nil
+		}
+		for _, name := range names {
+			if _, ok := fields[name]; ok {
+				return false, nil
+			}
+		}
+		return false, errors.New("object matches neither variant: no known field and no $ref")
+	}
+	if len(fields) > 1 {
+		return false, errors.New("ambiguous object: $ref cannot be combined with other fields")
+	}
+	return true, nil
+}
+// isJSONArray reports whether a JSON value is an array.
+func isJSONArray(bytes []byte) bool {
+	return strings.HasPrefix(strings.TrimLeft(string(bytes), " \t\r\n"), "[")
+}
+// unmarshalExtensions collects the ^x- specification extension fields of a JSON object.
+func unmarshalExtensions(bytes []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	var extensions map[string]json.RawMessage
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]json.RawMessage{}
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+// marshalExtensions marshals v and appends the ^x- specification extension fields, sorted by key.
+func marshalExtensions(v interface{}, extensions map[string]json.RawMessage) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return bytes, err
+	}
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	out := append([]byte{}, bytes[:len(bytes)-1]...)
+	for _, key := range keys {
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, name...)
+		out = append(out, ':')
+		out = append(out, extensions[key]...)
+	}
+	return append(out, '}'), nil
+}
+// decodeInteger decodes a JSON number that has no fractional part, however it is written.
+func decodeInteger(bytes []byte) (int64, error) {
+	var n json.Number
+	if strings.HasPrefix(string(bytes), `"`) || json.Unmarshal(bytes, &n) != nil {
+		return 0, errors.New("json: cannot unmarshal " + string(bytes) + " into an integer")
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errors.New("json: cannot unmarshal " + string(n) + " into an integer")
+	}
+	return int64(f), nil
 }
 
 const RawOpenrpcDocument = "{\"$schema\":\"https://meta.json-schema.tools/\",\"$id\":\"https://meta.open-rpc.org/\",\"title\":\"openrpcDocument\",\"type\":\"object\",\"required\":[\"info\",\"methods\",\"openrpc\"],\"additionalProperties\":false,\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}},\"properties\":{\"openrpc\":{\"title\":\"openrpc\",\"type\":\"string\",\"enum\":[\"1.3.2\",\"1.3.1\",\"1.3.0\",\"1.2.6\",\"1.2.5\",\"1.2.4\",\"1.2.3\",\"1.2.2\",\"1.2.1\",\"1.2.0\",\"1.1.12\",\"1.1.11\",\"1.1.10\",\"1.1.9\",\"1.1.8\",\"1.1.7\",\"1.1.6\",\"1.1.5\",\"1.1.4\",\"1.1.3\",\"1.1.2\",\"1.1.1\",\"1.1.0\",\"1.0.0\",\"1.0.0-rc0\",\"1.0.0-rc1\"]},\"info\":{\"$ref\":\"#/definitions/infoObject\"},\"externalDocs\":{\"$ref\":\"#/definitions/externalDocumentationObject\"},\"servers\":{\"title\":\"servers\",\"type\":\"array\",\"additionalItems\":false,\"items\":{\"$ref\":\"#/definitions/serverObject\"}},\"methods\":{\"title\":\"methods\",\"type\":\"array\",\"additionalItems\":false,\"items\":{\"title\":\"methodOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/methodObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"components\":{\"title\":\"components\",\"type\":\"object\",\"properties\":{\"schemas\":{\"title\":\"schemaComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/JSONSchema\"}}},\"links\":{\"title\":\"linkComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/linkObject\"}}},\"errors\":{\"title\":\"errorComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/errorObject\"}}},\"examples\":{\"title\":\"exampleComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/exampleObject\"}}},\"examplePairings\":{\"title\":\"examplePairingComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/examplePairingObject\"}}},\"contentDescriptors\":{\"title\":\"contentDescriptorComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/contentDescriptorObject\"}}},\"tags\":{\"title\":\"tagComponents\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/tagObject\"}}}}},\"$schema\":{\"title\":\"metaSchema\",\"description\":\"JSON Schema URI (used by some editors)\",\"type\":\"string\",\"default\":\"https://meta.open-rpc.org/\"}},\"definitions\":{\"specificationExtension\":{\"title\":\"specificationExtension\"},\"JSONSchema\":{\"$ref\":\"https://meta.json-schema.tools\"},\"referenceObject\":{\"title\":\"referenceObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"$ref\"],\"properties\":{\"$ref\":{\"$ref\":\"https://meta.json-schema.tools/#/definitions/JSONSchemaObject/properties/$ref\"}}},\"errorObject\":{\"title\":\"errorObject\",\"type\":\"object\",\"description\":\"Defines an application level error.\",\"additionalProperties\":false,\"required\":[\"code\",\"message\"],\"properties\":{\"code\":{\"title\":\"errorObjectCode\",\"description\":\"A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.\",\"type\":\"integer\"},\"message\":{\"title\":\"errorObjectMessage\",\"description\":\"A String providing a short description of the error. The message SHOULD be limited to a concise single sentence.\",\"type\":\"string\"},\"data\":{\"title\":\"errorObjectData\",\"description\":\"A Primitive or Structured value that contains additional information about the error. This may be omitted. The value of this member is defined by the Server (e.g. detailed error information, nested errors etc.).\"}}},\"licenseObject\":{\"title\":\"licenseObject\",\"type\":\"object\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"licenseObjectName\",\"type\":\"string\"},\"url\":{\"title\":\"licenseObjectUrl\",\"type\":\"string\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"contactObject\":{\"title\":\"contactObject\",\"type\":\"object\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"contactObjectName\",\"type\":\"string\"},\"email\":{\"title\":\"contactObjectEmail\",\"type\":\"string\"},\"url\":{\"title\":\"contactObjectUrl\",\"type\":\"string\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"infoObject\":{\"title\":\"infoObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"title\",\"version\"],\"properties\":{\"title\":{\"title\":\"infoObjectProperties\",\"type\":\"string\"},\"description\":{\"title\":\"infoObjectDescription\",\"type\":\"string\"},\"termsOfService\":{\"title\":\"infoObjectTermsOfService\",\"type\":\"string\",\"format\":\"uri\"},\"version\":{\"title\":\"infoObjectVersion\",\"type\":\"string\"},\"contact\":{\"$ref\":\"#/definitions/contactObject\"},\"license\":{\"$ref\":\"#/definitions/licenseObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"serverObject\":{\"title\":\"serverObject\",\"type\":\"object\",\"required\":[\"url\"],\"additionalProperties\":false,\"properties\":{\"url\":{\"title\":\"serverObjectUrl\",\"type\":\"string\",\"format\":\"uri\"},\"name\":{\"title\":\"serverObjectName\",\"type\":\"string\"},\"description\":{\"title\":\"serverObjectDescription\",\"type\":\"string\"},\"summary\":{\"title\":\"serverObjectSummary\",\"type\":\"string\"},\"variables\":{\"title\":\"serverObjectVariables\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"title\":\"serverObjectVariable\",\"type\":\"object\",\"required\":[\"default\"],\"properties\":{\"default\":{\"title\":\"serverObjectVariableDefault\",\"type\":\"string\"},\"description\":{\"title\":\"serverObjectVariableDescription\",\"type\":\"string\"},\"enum\":{\"title\":\"serverObjectVariableEnum\",\"type\":\"array\",\"items\":{\"title\":\"serverObjectVariableEnumItem\",\"type\":\"string\"}}}}}}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"linkObject\":{\"title\":\"linkObject\",\"type\":\"object\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"linkObjectName\",\"type\":\"string\",\"minLength\":1},\"summary\":{\"title\":\"linkObjectSummary\",\"type\":\"string\"},\"method\":{\"title\":\"linkObjectMethod\",\"type\":\"string\"},\"description\":{\"title\":\"linkObjectDescription\",\"type\":\"string\"},\"params\":{\"title\":\"linkObjectParams\"},\"server\":{\"title\":\"linkObjectServer\",\"$ref\":\"#/definitions/serverObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"externalDocumentationObject\":{\"title\":\"externalDocumentationObject\",\"type\":\"object\",\"additionalProperties\":false,\"description\":\"information about external documentation\",\"required\":[\"url\"],\"properties\":{\"description\":{\"title\":\"externalDocumentationObjectDescription\",\"type\":\"string\"},\"url\":{\"title\":\"externalDocumentationObjectUrl\",\"type\":\"string\",\"format\":\"uri\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"methodObject\":{\"title\":\"methodObject\",\"type\":\"object\",\"required\":[\"name\",\"params\"],\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"methodObjectName\",\"description\":\"The cannonical name for the method. The name MUST be unique within the methods array.\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"methodObjectDescription\",\"description\":\"A verbose explanation of the method behavior. GitHub Flavored Markdown syntax MAY be used for rich text representation.\",\"type\":\"string\"},\"summary\":{\"title\":\"methodObjectSummary\",\"description\":\"A short summary of what the method does.\",\"type\":\"string\"},\"servers\":{\"title\":\"servers\",\"type\":\"array\",\"additionalItems\":false,\"items\":{\"$ref\":\"#/definitions/serverObject\"}},\"tags\":{\"title\":\"methodObjectTags\",\"type\":\"array\",\"items\":{\"title\":\"tagOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/tagObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"paramStructure\":{\"title\":\"methodObjectParamStructure\",\"type\":\"string\",\"description\":\"Format the server expects the params. Defaults to 'either'.\",\"enum\":[\"by-position\",\"by-name\",\"either\"],\"default\":\"either\"},\"params\":{\"title\":\"methodObjectParams\",\"type\":\"array\",\"items\":{\"title\":\"contentDescriptorOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/contentDescriptorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"result\":{\"title\":\"methodObjectResult\",\"oneOf\":[{\"$ref\":\"#/definitions/contentDescriptorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]},\"errors\":{\"title\":\"methodObjectErrors\",\"description\":\"Defines an application level error.\",\"type\":\"array\",\"items\":{\"title\":\"errorOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/errorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"links\":{\"title\":\"methodObjectLinks\",\"type\":\"array\",\"items\":{\"title\":\"linkOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/linkObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"examples\":{\"title\":\"methodObjectExamples\",\"type\":\"array\",\"items\":{\"title\":\"examplePairingOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/examplePairingObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"deprecated\":{\"title\":\"methodObjectDeprecated\",\"type\":\"boolean\",\"default\":false},\"externalDocs\":{\"$ref\":\"#/definitions/externalDocumentationObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"tagObject\":{\"title\":\"tagObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"name\"],\"properties\":{\"name\":{\"title\":\"tagObjectName\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"tagObjectDescription\",\"type\":\"string\"},\"externalDocs\":{\"$ref\":\"#/definitions/externalDocumentationObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"exampleObject\":{\"title\":\"exampleObject\",\"type\":\"object\",\"required\":[\"name\",\"value\"],\"properties\":{\"summary\":{\"title\":\"exampleObjectSummary\",\"type\":\"string\"},\"value\":{\"title\":\"exampleObjectValue\"},\"description\":{\"title\":\"exampleObjectDescription\",\"type\":\"string\"},\"name\":{\"title\":\"exampleObjectName\",\"type\":\"string\",\"minLength\":1}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"examplePairingObject\":{\"title\":\"examplePairingObject\",\"type\":\"object\",\"required\":[\"name\",\"params\"],\"properties\":{\"name\":{\"title\":\"examplePairingObjectName\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"examplePairingObjectDescription\",\"type\":\"string\"},\"params\":{\"title\":\"examplePairingObjectParams\",\"type\":\"array\",\"items\":{\"title\":\"exampleOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/exampleObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"result\":{\"title\":\"examplePairingObjectResult\",\"oneOf\":[{\"$ref\":\"#/definitions/exampleObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}}},\"contentDescriptorObject\":{\"title\":\"contentDescriptorObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"name\",\"schema\"],\"properties\":{\"name\":{\"title\":\"contentDescriptorObjectName\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"contentDescriptorObjectDescription\",\"type\":\"string\"},\"summary\":{\"title\":\"contentDescriptorObjectSummary\",\"type\":\"string\"},\"schema\":{\"$ref\":\"#/definitions/JSONSchema\"},\"required\":{\"title\":\"contentDescriptorObjectRequired\",\"type\":\"boolean\",\"default\":false},\"deprecated\":{\"title\":\"contentDescriptorObjectDeprecated\",\"type\":\"boolean\",\"default\":false}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}}}}"
@@ -0,0 +1,167 @@
+package v1_3
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// roundTrip decodes in into v and checks that encoding v gives in back, byte for byte
+// once in is compacted.
+func roundTrip(t *testing.T, in string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(in), v); err != nil {
+		t.Fatalf("unmarshal %s: %v", in, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", in, err)
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, []byte(in)); err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != want.String() {
+		t.Errorf("round trip changed the document\n got: %s\nwant: %s", out, want.String())
+	}
+}
+
+func TestComponentsRoundTrip(t *testing.T) {
+	in := `{
+		"schemas": {"PetId": {"type": "integer"}},
+		"errors": {"NotFound": {"code": 404, "message": "not found"}},
+		"contentDescriptors": {"petId": {"name": "petId", "schema": {"$ref": "#/components/schemas/PetId"}, "required": true}},
+		"tags": {"pets": {"name": "pets"}}
+	}`
+	var c Components
+	roundTrip(t, in, &c)
+
+	if s := (*c.Schemas)["PetId"]; s.JSONSchemaObject == nil || *s.JSONSchemaObject.Type.SimpleTypes != SimpleTypesEnum2 {
+		t.Errorf("schemas/PetId = %+v", s)
+	}
+	if e := (*c.Errors)["NotFound"]; *e.Code != 404 || *e.Message != "not found" {
+		t.Errorf("errors/NotFound = %+v", e)
+	}
+	if d := (*c.ContentDescriptors)["petId"]; *d.Name != "petId" || !*d.Required {
+		t.Errorf("contentDescriptors/petId = %+v", d)
+	}
+	if tag := (*c.Tags)["pets"]; *tag.Name != "pets" {
+		t.Errorf("tags/pets = %+v", tag)
+	}
+}
+
+func TestExtensionsRoundTrip(t *testing.T) {
+	in := `{
+		"openrpc": "1.3.2",
+		"info": {
+			"title": "Petstore",
+			"version": "1.0.0",
+			"contact": {"name": "Support", "x-team": "pets"},
+			"license": {"name": "MIT", "x-spdx": "MIT"},
+			"x-logo": {"url": "https://example.com/logo.png"}
+		},
+		"externalDocs": {"url": "https://example.com/docs", "x-audience": "public"},
+		"servers": [{"url": "https://example.com", "x-region": "eu"}],
+		"methods": [
+			{
+				"name": "get_pet",
+				"tags": [{"name": "pets", "x-order": 1}],
+				"params": [{"name": "id", "schema": {"type": "integer"}, "x-example": 7}],
+				"result": {"name": "pet", "schema": true},
+				"links": [{"method": "get_owner", "server": {"url": "https://owners.example.com", "x-internal": true}, "x-link": "owner"}],
+				"examples": [{"name": "fido", "params": [{"value": 7, "name": "id", "x-generated": false}]}],
+				"x-auth": ["read"],
+				"x-rate-limit": {"requests": 10, "window": "1s"}
+			}
+		],
+		"x-a": null,
+		"x-z": "last"
+	}`
+	var doc OpenrpcDocument
+	roundTrip(t, in, &doc)
+
+	method := (*doc.Methods)[0].Value()
+	if got := string(method.Extensions["x-rate-limit"]); got != `{"requests": 10, "window": "1s"}` {
+		t.Errorf("x-rate-limit = %s", got)
+	}
+	if got := string(doc.Info.Contact.Extensions["x-team"]); got != `"pets"` {
+		t.Errorf("x-team = %s", got)
+	}
+	if got := string(doc.Extensions["x-a"]); got != "null" {
+		t.Errorf("x-a = %s", got)
+	}
+	if _, ok := method.Extensions["name"]; ok {
+		t.Error("Extensions holds the name field")
+	}
+}
+
+func TestExtensionsMarshalSortedAfterFields(t *testing.T) {
+	url := ExternalDocumentationObjectUrl("https://example.com")
+	docs := ExternalDocumentationObject{Url: &url, Extensions: map[string]json.RawMessage{
+		"x-b":         json.RawMessage(`2`),
+		"x-a":         json.RawMessage(`1`),
+		"description": json.RawMessage(`"not an extension"`),
+	}}
+	out, err := json.Marshal(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"url":"https://example.com","x-a":1,"x-b":2}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestOrRefDiscriminatesOnRef(t *testing.T) {
+	var params MethodObjectParams
+	in := `[{"name": "id", "schema": true}, {"$ref": "#/components/contentDescriptors/id"}]`
+	roundTrip(t, in, &params)
+	if params[0].IsRef() || *params[0].Value().Name != "id" {
+		t.Errorf("params[0] = %+v, want a content descriptor", params[0])
+	}
+	if !params[1].IsRef() || params[1].Value() != nil || *params[1].Ref().Ref != "#/components/contentDescriptors/id" {
+		t.Errorf("params[1] = %+v, want a reference", params[1])
+	}
+}
+
+func TestOrRefRejectsRefSiblings(t *testing.T) {
+	var method MethodOrReference
+	in := `{"$ref": "#/components/methods/get_pet", "name": "get_pet"}`
+	if err := json.Unmarshal([]byte(in), &method); err == nil {
+		t.Errorf("got %+v, want an ambiguity error", method)
+	}
+}
+
+func TestOrRefRejectsUnknownObjects(t *testing.T) {
+	for _, in := range []string{`{}`, `{"x-note": "no known field"}`, `{"nmae": "get_pet"}`} {
+		var method MethodOrReference
+		if err := json.Unmarshal([]byte(in), &method); err == nil {
+			t.Errorf("%s: got %+v, want an error", in, method)
+		}
+	}
+	var doc OpenrpcDocument
+	in := `{"openrpc": "1.3.2", "info": {"title": "t", "version": "1"}, "methods": [{}]}`
+	if err := json.Unmarshal([]byte(in), &doc); err == nil {
+		t.Error("a document with an empty method decoded")
+	}
+}
+
+func TestOrRefRejectsNonObjects(t *testing.T) {
+	for _, in := range []string{`"#/components/tags/pets"`, `[]`, `null`, `{"$ref": 7}`} {
+		var tag TagOrReference
+		if err := json.Unmarshal([]byte(in), &tag); err == nil {
+			t.Errorf("%s: got %+v, want an error", in, tag)
+		}
+	}
+}
+
+func TestUnionRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		`{"items":{"type":"string"}}`,
+		`{"items":[{"type":"string"},true]}`,
+		`{"type":"string"}`,
+		`{"type":["string","null"]}`,
+		`{"dependencies":{"billing":{"required":["address"]},"card":["billing"]}}`,
+	} {
+		roundTrip(t, in, &JSONSchemaObject{})
+	}
+}
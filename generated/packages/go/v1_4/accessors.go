@@ -0,0 +1,229 @@
+package v1_4
+
+import (
+	"github.com/zcstarr/spec-types/generated/packages/go/internal/deref"
+	"github.com/zcstarr/spec-types/generated/packages/go/openrpc"
+)
+
+var (
+	_ openrpc.Document          = (*OpenrpcDocument)(nil)
+	_ openrpc.Method            = (*MethodObject)(nil)
+	_ openrpc.ContentDescriptor = (*ContentDescriptorObject)(nil)
+	_ openrpc.Schema            = (*JSONSchema)(nil)
+)
+
+// GetOpenrpc returns the specification version in the document's "openrpc" field.
+func (d *OpenrpcDocument) GetOpenrpc() string {
+	return deref.String(d.Openrpc)
+}
+
+// GetTitle returns the title of the application, or "" without an info object.
+func (d *OpenrpcDocument) GetTitle() string {
+	if d.Info == nil {
+		return ""
+	}
+	return deref.String(d.Info.Title)
+}
+
+// GetDescription returns the description of the application, or "" when it has none.
+func (d *OpenrpcDocument) GetDescription() string {
+	if d.Info == nil {
+		return ""
+	}
+	return deref.String(d.Info.Description)
+}
+
+// GetVersion returns the version of the API, or "" without an info object.
+func (d *OpenrpcDocument) GetVersion() string {
+	if d.Info == nil {
+		return ""
+	}
+	return deref.String(d.Info.Version)
+}
+
+// GetMethods returns the methods defined inline, in order. Methods that are Reference
+// Objects are skipped rather than resolved; use the resolve package to follow them.
+func (d *OpenrpcDocument) GetMethods() []openrpc.Method {
+	var methods []openrpc.Method
+	if d.Methods != nil {
+		for _, m := range *d.Methods {
+			if v := m.Value(); v != nil {
+				methods = append(methods, v)
+			}
+		}
+	}
+	return methods
+}
+
+// GetName returns the name of the method.
+func (m *MethodObject) GetName() string {
+	return deref.String(m.Name)
+}
+
+// GetSummary returns the short summary of the method.
+func (m *MethodObject) GetSummary() string {
+	return deref.String(m.Summary)
+}
+
+// GetDescription returns the verbose explanation of the method.
+func (m *MethodObject) GetDescription() string {
+	return deref.String(m.Description)
+}
+
+// GetParamStructure returns the method's paramStructure, "either" when it is not set.
+func (m *MethodObject) GetParamStructure() string {
+	if m.ParamStructure == nil {
+		return string(MethodObjectParamStructureEnum2)
+	}
+	return string(*m.ParamStructure)
+}
+
+// GetParams returns the params defined inline, in order. Params that are Reference
+// Objects are skipped rather than resolved, so indexes may differ from the document's.
+func (m *MethodObject) GetParams() []openrpc.ContentDescriptor {
+	var params []openrpc.ContentDescriptor
+	if m.Params != nil {
+		for _, p := range *m.Params {
+			if v := p.Value(); v != nil {
+				params = append(params, v)
+			}
+		}
+	}
+	return params
+}
+
+// GetResult returns the result when it is defined inline, or nil when the method has
+// no result or its result is a Reference Object.
+func (m *MethodObject) GetResult() openrpc.ContentDescriptor {
+	if m.Result == nil || m.Result.Value() == nil {
+		return nil
+	}
+	return m.Result.Value()
+}
+
+// GetTags returns the names of the tags defined inline. Tags that are Reference
+// Objects are skipped rather than resolved.
+func (m *MethodObject) GetTags() []string {
+	var tags []string
+	if m.Tags != nil {
+		for _, t := range *m.Tags {
+			if v := t.Value(); v != nil {
+				tags = append(tags, deref.String(v.Name))
+			}
+		}
+	}
+	return tags
+}
+
+// IsDeprecated reports whether the method is declared deprecated.
+func (m *MethodObject) IsDeprecated() bool {
+	return m.Deprecated != nil && bool(*m.Deprecated)
+}
+
+// GetName returns the name of the content.
+func (c *ContentDescriptorObject) GetName() string {
+	return deref.String(c.Name)
+}
+
+// GetSummary returns the short summary of the content.
+func (c *ContentDescriptorObject) GetSummary() string {
+	return deref.String(c.Summary)
+}
+
+// GetDescription returns the verbose explanation of the content.
+func (c *ContentDescriptorObject) GetDescription() string {
+	return deref.String(c.Description)
+}
+
+// IsRequired reports whether the content is declared required.
+func (c *ContentDescriptorObject) IsRequired() bool {
+	return c.Required != nil && bool(*c.Required)
+}
+
+// IsDeprecated reports whether the content is declared deprecated.
+func (c *ContentDescriptorObject) IsDeprecated() bool {
+	return c.Deprecated != nil && bool(*c.Deprecated)
+}
+
+// GetSchema returns the schema of the content, or nil when it has none.
+func (c *ContentDescriptorObject) GetSchema() openrpc.Schema {
+	if c.Schema == nil {
+		return nil
+	}
+	schema := JSONSchema(*c.Schema)
+	return &schema
+}
+
+// Boolean returns the value of a boolean schema; ok is false for object schemas.
+func (s *JSONSchema) Boolean() (bool, bool) {
+	if s.JSONSchemaBoolean == nil {
+		return false, false
+	}
+	return bool(*s.JSONSchemaBoolean), true
+}
+
+// GetRef returns the schema's "$ref", or "" when it has none. It is not resolved.
+func (s *JSONSchema) GetRef() string {
+	if s.JSONSchemaObject == nil {
+		return ""
+	}
+	return deref.String(s.JSONSchemaObject.Ref)
+}
+
+// GetTitle returns the schema's "title", or "" for boolean schemas.
+func (s *JSONSchema) GetTitle() string {
+	if s.JSONSchemaObject == nil {
+		return ""
+	}
+	return deref.String(s.JSONSchemaObject.Title)
+}
+
+// GetDescription returns the schema's "description", or "" for boolean schemas.
+func (s *JSONSchema) GetDescription() string {
+	if s.JSONSchemaObject == nil {
+		return ""
+	}
+	return deref.String(s.JSONSchemaObject.Description)
+}
+
+// GetTypes returns the schema's "type", as a list even when it names a single type.
+func (s *JSONSchema) GetTypes() []string {
+	if s.JSONSchemaObject == nil || s.JSONSchemaObject.Type == nil {
+		return nil
+	}
+	t := s.JSONSchemaObject.Type
+	if t.SimpleTypes != nil {
+		return []string{string(*t.SimpleTypes)}
+	}
+	var types []string
+	if t.ArrayOfSimpleTypes != nil {
+		for _, st := range *t.ArrayOfSimpleTypes {
+			types = append(types, string(st))
+		}
+	}
+	return types
+}
+
+// GetRequired returns the names the schema's "required" lists.
+func (s *JSONSchema) GetRequired() []string {
+	if s.JSONSchemaObject == nil || s.JSONSchemaObject.Required == nil {
+		return nil
+	}
+	var required []string
+	for _, r := range *s.JSONSchemaObject.Required {
+		required = append(required, string(r))
+	}
+	return required
+}
+
+// GetProperties returns the schemas of the properties the schema's "properties" defines.
+func (s *JSONSchema) GetProperties() map[string]openrpc.Schema {
+	if s.JSONSchemaObject == nil || s.JSONSchemaObject.Properties == nil {
+		return nil
+	}
+	properties := map[string]openrpc.Schema{}
+	for name, p := range *s.JSONSchemaObject.Properties {
+		properties[name] = &p
+	}
+	return properties
+}
@@ -0,0 +1,180 @@
+package v1_4
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_3"
+)
+
+// Known OpenRPC specification versions described by this package.
+const (
+	Openrpc1_4_0 Openrpc = "1.4.0"
+)
+
+// LatestOpenrpc is the most recent specification version described by this package.
+const LatestOpenrpc = Openrpc1_4_0
+
+// openrpcPattern is the pattern the meta-schema requires of the openrpc field.
+var openrpcPattern = regexp.MustCompile(metaSchemaOpenrpcRegex())
+
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// OpenrpcVersion is a parsed [semantic version](https://semver.org/spec/v2.0.0.html) of the OpenRPC Specification.
+type OpenrpcVersion struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+// ParseOpenrpcVersion parses a semantic version string such as "1.4.0" or "1.0.0-rc1".
+func ParseOpenrpcVersion(s string) (OpenrpcVersion, error) {
+	m := semverPattern.FindStringSubmatch(s)
+	if m == nil {
+		return OpenrpcVersion{}, fmt.Errorf("invalid OpenRPC version %q: not a semantic version", s)
+	}
+	var v OpenrpcVersion
+	for i, part := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			return OpenrpcVersion{}, fmt.Errorf("invalid OpenRPC version %q: %w", s, err)
+		}
+		*part = n
+	}
+	v.Prerelease, v.Build = m[4], m[5]
+	return v, nil
+}
+
+// String returns the version in its canonical semantic version form.
+func (v OpenrpcVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v precedes, equals or follows o.
+// Precedence follows the semantic versioning rules, so build metadata is ignored.
+func (v OpenrpcVersion) Compare(o OpenrpcVersion) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// Package reports the name of the Go package whose types describe documents of version v:
+// "v1_4" for the versions matching the meta-schema's pattern, 1.4.x without a prerelease or
+// build, and "v1_3" for the releases in v1_3.KnownOpenrpc. It returns an empty string for
+// versions no package supports.
+func (v OpenrpcVersion) Package() string {
+	switch {
+	case openrpcPattern.MatchString(v.String()):
+		return "v1_4"
+	case v1_3.Openrpc(v.String()).Known():
+		return "v1_3"
+	default:
+		return ""
+	}
+}
+
+// Version parses the specification version of the document.
+func (o Openrpc) Version() (OpenrpcVersion, error) {
+	return ParseOpenrpcVersion(string(o))
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Only 1.4.x versions are accepted, mirroring the pattern of the meta-schema.
+func (o *Openrpc) UnmarshalJSON(bytes []byte) error {
+	var s string
+	if err := json.Unmarshal(bytes, &s); err != nil {
+		return err
+	}
+	v, err := ParseOpenrpcVersion(s)
+	if err != nil {
+		return err
+	}
+	if pkg := v.Package(); pkg != "v1_4" {
+		if pkg == "" {
+			return fmt.Errorf("unsupported OpenRPC version %q", s)
+		}
+		return fmt.Errorf("unsupported OpenRPC version %q: use the %s package", s, pkg)
+	}
+	*o = Openrpc(s)
+	return nil
+}
+
+// metaSchemaOpenrpcRegex returns the pattern of the openrpc field in RawOpenrpcDocument.
+func metaSchemaOpenrpcRegex() string {
+	var schema struct {
+		Properties struct {
+			Openrpc struct {
+				Regex string `json:"regex"`
+			} `json:"openrpc"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(RawOpenrpcDocument), &schema); err != nil || schema.Properties.Openrpc.Regex == "" {
+		panic("v1_4: the meta-schema has no pattern for the openrpc field")
+	}
+	return schema.Properties.Openrpc.Regex
+}
+
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(as) - len(bs))
+}
+
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+	switch {
+	case aNumeric && bNumeric:
+		if len(a) != len(b) {
+			return sign(len(a) - len(b))
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func isNumeric(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
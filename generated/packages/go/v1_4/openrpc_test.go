@@ -0,0 +1,95 @@
+package v1_4
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseOpenrpcVersion(t *testing.T) {
+	v, err := ParseOpenrpcVersion("1.0.0-rc1+build.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (OpenrpcVersion{Major: 1, Prerelease: "rc1", Build: "build.5"}); v != want {
+		t.Errorf("got %+v, want %+v", v, want)
+	}
+	if s := v.String(); s != "1.0.0-rc1+build.5" {
+		t.Errorf("String() = %q", s)
+	}
+	for _, invalid := range []string{"", "1.4", "v1.4.0", "01.4.0", "1.4.0-", "1.4.0+"} {
+		if _, err := ParseOpenrpcVersion(invalid); err == nil {
+			t.Errorf("ParseOpenrpcVersion(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestOpenrpcVersionCompare(t *testing.T) {
+	// Ordered by precedence, from the semantic versioning specification.
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.3.2", "1.4.0", "1.10.0", "2.0.0"}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			va, _ := ParseOpenrpcVersion(a)
+			vb, _ := ParseOpenrpcVersion(b)
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := va.Compare(vb); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	a, _ := ParseOpenrpcVersion("1.4.0+a")
+	b, _ := ParseOpenrpcVersion("1.4.0+b")
+	if a.Compare(b) != 0 {
+		t.Error("build metadata affects precedence")
+	}
+}
+
+func TestOpenrpcVersionPackage(t *testing.T) {
+	for version, want := range map[string]string{
+		"1.4.0":     "v1_4",
+		"1.4.7":     "v1_4",
+		"1.4.0-rc1": "",
+		"1.4.0+b.1": "",
+		"1.3.2":     "v1_3",
+		"1.2.6":     "v1_3",
+		"1.1.12":    "v1_3",
+		"1.0.0-rc0": "v1_3",
+		"1.0.0-rc1": "v1_3",
+		"1.2.7":     "",
+		"1.1.13":    "",
+		"1.0.0-rc2": "",
+		"0.9.0":     "",
+		"1.5.0":     "",
+		"2.0.0":     "",
+	} {
+		v, err := ParseOpenrpcVersion(version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.Package(); got != want {
+			t.Errorf("Package(%s) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestOpenrpcUnmarshal(t *testing.T) {
+	var o Openrpc
+	if err := json.Unmarshal([]byte(`"1.4.1"`), &o); err != nil || o != "1.4.1" {
+		t.Errorf("got %q, %v", o, err)
+	}
+	for _, invalid := range []string{`"1.3.2"`, `"1.2.7"`, `"1.4"`, `"1.4.0-rc1"`, `"1.4.0+build"`, `"2.0.0"`, `14`} {
+		if err := json.Unmarshal([]byte(invalid), &o); err == nil {
+			t.Errorf("%s: accepted", invalid)
+		}
+	}
+}
+
+func TestOpenrpcPatternFromMetaSchema(t *testing.T) {
+	if got, want := openrpcPattern.String(), `^1\.4\.\d+$`; got != want {
+		t.Errorf("openrpcPattern = %s, want %s", got, want)
+	}
+}
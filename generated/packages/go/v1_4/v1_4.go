@@ -2,6 +2,9 @@ package v1_4
 
 import "encoding/json"
 import "errors"
+import "math"
+import "sort"
+import "strings"
 // This string MUST be the [semantic version number](https://semver.org/spec/v2.0.0.html) of the [OpenRPC Specification version](#versions) that the OpenRPC document uses. The `openrpc` field SHOULD be used by tooling specifications and clients to interpret the OpenRPC document. This is *not* related to the API [`info.version`](#info-version) string.
 type Openrpc string
 // The title of the application.
@@ -22,9 +25,31 @@ type ContactObjectUrl string
 type SpecificationExtension interface{}
 // Contact information for the exposed API.
 type ContactObject struct {
-	Name  *ContactObjectName  `json:"name,omitempty"`
-	Email *ContactObjectEmail `json:"email,omitempty"`
-	Url   *ContactObjectUrl   `json:"url,omitempty"`
+	Name       *ContactObjectName         `json:"name,omitempty"`
+	Email      *ContactObjectEmail        `json:"email,omitempty"`
+	Url        *ContactObjectUrl          `json:"url,omitempty"`
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ContactObject) UnmarshalJSON(bytes []byte) error {
+	type plain ContactObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ContactObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ContactObject) MarshalJSON() ([]byte, error) {
+	type plain ContactObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 // The license name used for the API.
 type LicenseObjectName string
@@ -32,11 +57,62 @@ type LicenseObjectName string
 type LicenseObjectUrl string
 // License information for the exposed API.
 type LicenseObject struct {
-	Name *LicenseObjectName `json:"name,omitempty"`
-	Url  *LicenseObjectUrl  `json:"url,omitempty"`
+	Name       *LicenseObjectName         `json:"name,omitempty"`
+	Url        *LicenseObjectUrl          `json:"url,omitempty"`
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LicenseObject) UnmarshalJSON(bytes []byte) error {
+	type plain LicenseObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = LicenseObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LicenseObject) MarshalJSON() ([]byte, error) {
+	type plain LicenseObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 // The object provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.
-type InfoObject interface{}
+type InfoObject struct {
+	Title          *InfoObjectTitle           `json:"title"`
+	Description    *InfoObjectDescription     `json:"description,omitempty"`
+	TermsOfService *InfoObjectTermsOfService  `json:"termsOfService,omitempty"`
+	Version        *InfoObjectVersion         `json:"version"`
+	Contact        *ContactObject             `json:"contact,omitempty"`
+	License        *LicenseObject             `json:"license,omitempty"`
+	Extensions     map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *InfoObject) UnmarshalJSON(bytes []byte) error {
+	type plain InfoObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = InfoObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o InfoObject) MarshalJSON() ([]byte, error) {
+	type plain InfoObject
+	return marshalExtensions(plain(o), o.Extensions)
+}
 // A verbose explanation of the documentation. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.
 type ExternalDocumentationObjectDescription string
 // The URL for the target documentation. Value MUST be in the format of a URL.
@@ -45,6 +121,28 @@ type ExternalDocumentationObjectUrl string
 type ExternalDocumentationObject struct {
 	Description *ExternalDocumentationObjectDescription `json:"description,omitempty"`
 	Url         *ExternalDocumentationObjectUrl         `json:"url"`
+	Extensions  map[string]json.RawMessage              `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ExternalDocumentationObject) UnmarshalJSON(bytes []byte) error {
+	type plain ExternalDocumentationObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ExternalDocumentationObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ExternalDocumentationObject) MarshalJSON() ([]byte, error) {
+	type plain ExternalDocumentationObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 // A URL to the target host. This URL supports Server Variables and MAY be relative, to indicate that the host location is relative to the location where the OpenRPC document is being served. [Server Variables](#server-variables) are passed into the [Runtime Expression](#runtime-expression) to produce a server URL.
 type ServerObjectUrl string
@@ -69,14 +167,36 @@ type ServerObjectVariable struct {
 	Enum        *ServerObjectVariableEnum        `json:"enum,omitempty"`
 }
 // A map between a variable name and its value. The value is passed into the [Runtime Expression](#runtime-expression) to produce a server URL.
-type ServerObjectVariables map[string]interface{}
+type ServerObjectVariables map[string]ServerObjectVariable
 // A object representing a Server
 type ServerObject struct {
-	Url         *ServerObjectUrl         `json:"url"`
-	Name        *ServerObjectName        `json:"name,omitempty"`
-	Description *ServerObjectDescription `json:"description,omitempty"`
-	Summary     *ServerObjectSummary     `json:"summary,omitempty"`
-	Variables   *ServerObjectVariables   `json:"variables,omitempty"`
+	Url         *ServerObjectUrl           `json:"url"`
+	Name        *ServerObjectName          `json:"name,omitempty"`
+	Description *ServerObjectDescription   `json:"description,omitempty"`
+	Summary     *ServerObjectSummary       `json:"summary,omitempty"`
+	Variables   *ServerObjectVariables     `json:"variables,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ServerObject) UnmarshalJSON(bytes []byte) error {
+	type plain ServerObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ServerObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ServerObject) MarshalJSON() ([]byte, error) {
+	type plain ServerObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
 type AlwaysFalse interface{}
 // An array of Server Objects, which provide connectivity information to a target server. If the `servers` property is not provided, or is an empty array, the default value would be a [Server Object](#server-object) with a [url](#server-url) value of `localhost`. 
@@ -96,41 +216,34 @@ type TagObject struct {
 	Name         *TagObjectName               `json:"name"`
 	Description  *TagObjectDescription        `json:"description,omitempty"`
 	ExternalDocs *ExternalDocumentationObject `json:"externalDocs,omitempty"`
-}
-type Ref string
-type ReferenceObject struct {
-	Ref *Ref `json:"$ref"`
-}
-type TagOrReference struct {
-	TagObject       *TagObject
-	ReferenceObject *ReferenceObject
+	Extensions   map[string]json.RawMessage   `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *TagOrReference) UnmarshalJSON(bytes []byte) error {
-	var myTagObject TagObject
-	if err := json.Unmarshal(bytes, &myTagObject); err == nil {
-		o.TagObject = &myTagObject
-		return nil
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *TagObject) UnmarshalJSON(bytes []byte) error {
+	type plain TagObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = TagObject(p)
+	o.Extensions = extensions
+	return nil
 }
-func (o TagOrReference) MarshalJSON() ([]byte, error) {
-	if o.TagObject != nil {
-		return json.Marshal(o.TagObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o TagObject) MarshalJSON() ([]byte, error) {
+	type plain TagObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
+type Ref string
+type ReferenceObject struct {
+	Ref *Ref `json:"$ref"`
+}
+type TagOrReference = OrRef[TagObject]
 // A list of tags for API documentation control. Tags can be used for logical grouping of methods by resources or any other qualifier.
 type MethodObjectTags []TagOrReference
 // Format the server expects the params. Defaults to 'either'.
@@ -164,7 +277,27 @@ type ExclusiveMaximum float64
 type Minimum float64
 type ExclusiveMinimum float64
 type NonNegativeInteger int64
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *NonNegativeInteger) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
+	}
+	*o = NonNegativeInteger(i)
+	return nil
+}
 type NonNegativeIntegerDefaultZero int64
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *NonNegativeIntegerDefaultZero) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
+	}
+	*o = NonNegativeIntegerDefaultZero(i)
+	return nil
+}
 type Pattern string
 // Always valid if true. Never valid if false. Is constant.
 type JSONSchemaBoolean bool
@@ -211,32 +344,33 @@ type Items struct {
 	JSONSchema  *JSONSchema
 	SchemaArray *SchemaArray
 }
-func (a *Items) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var myJSONSchema JSONSchema
-	if err := json.Unmarshal(bytes, &myJSONSchema); err == nil {
-		ok = true
-		a.JSONSchema = &myJSONSchema
-	}
-	var mySchemaArray SchemaArray
-	if err := json.Unmarshal(bytes, &mySchemaArray); err == nil {
-		ok = true
-		a.SchemaArray = &mySchemaArray
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into SchemaArray and any other value into JSONSchema, so exactly one variant is set.
+func (o *Items) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var mySchemaArray SchemaArray
+		if err := json.Unmarshal(bytes, &mySchemaArray); err != nil {
+			return err
+		}
+		*o = Items{SchemaArray: &mySchemaArray}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var myJSONSchema JSONSchema
+	if err := json.Unmarshal(bytes, &myJSONSchema); err != nil {
+		return err
+	}
+	*o = Items{JSONSchema: &myJSONSchema}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o Items) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.JSONSchema != nil {
-		out = append(out, o.JSONSchema)
+		return json.Marshal(o.JSONSchema)
 	}
 	if o.SchemaArray != nil {
-		out = append(out, o.SchemaArray)
+		return json.Marshal(o.SchemaArray)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
 type UniqueItems bool
 type StringDoaGddGA string
@@ -249,50 +383,51 @@ type StringArray []StringDoaGddGA
 // --- Default ---
 //
 // {}
-type Definitions map[string]interface{}
+type Definitions map[string]JSONSchema
 //
 // --- Default ---
 //
 // {}
-type Properties map[string]interface{}
+type Properties map[string]JSONSchema
 type PropertyNames interface{}
 //
 // --- Default ---
 //
 // {}
-type PatternProperties map[string]interface{}
+type PatternProperties map[string]JSONSchema
 type DependenciesSet struct {
 	JSONSchema  *JSONSchema
 	StringArray *StringArray
 }
-func (a *DependenciesSet) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var myJSONSchema JSONSchema
-	if err := json.Unmarshal(bytes, &myJSONSchema); err == nil {
-		ok = true
-		a.JSONSchema = &myJSONSchema
-	}
-	var myStringArray StringArray
-	if err := json.Unmarshal(bytes, &myStringArray); err == nil {
-		ok = true
-		a.StringArray = &myStringArray
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into StringArray and any other value into JSONSchema, so exactly one variant is set.
+func (o *DependenciesSet) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var myStringArray StringArray
+		if err := json.Unmarshal(bytes, &myStringArray); err != nil {
+			return err
+		}
+		*o = DependenciesSet{StringArray: &myStringArray}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var myJSONSchema JSONSchema
+	if err := json.Unmarshal(bytes, &myJSONSchema); err != nil {
+		return err
+	}
+	*o = DependenciesSet{JSONSchema: &myJSONSchema}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o DependenciesSet) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.JSONSchema != nil {
-		out = append(out, o.JSONSchema)
+		return json.Marshal(o.JSONSchema)
 	}
 	if o.StringArray != nil {
-		out = append(out, o.StringArray)
+		return json.Marshal(o.StringArray)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
-type Dependencies map[string]interface{}
+type Dependencies map[string]DependenciesSet
 type Enum []AlwaysTrue
 type SimpleTypes string
 const (
@@ -309,32 +444,33 @@ type Type struct {
 	SimpleTypes        *SimpleTypes
 	ArrayOfSimpleTypes *ArrayOfSimpleTypes
 }
-func (a *Type) UnmarshalJSON(bytes []byte) error {
-	var ok bool
-	var mySimpleTypes SimpleTypes
-	if err := json.Unmarshal(bytes, &mySimpleTypes); err == nil {
-		ok = true
-		a.SimpleTypes = &mySimpleTypes
-	}
-	var myArrayOfSimpleTypes ArrayOfSimpleTypes
-	if err := json.Unmarshal(bytes, &myArrayOfSimpleTypes); err == nil {
-		ok = true
-		a.ArrayOfSimpleTypes = &myArrayOfSimpleTypes
-	}
-	if ok {
+// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into ArrayOfSimpleTypes and any other value into SimpleTypes, so exactly one variant is set.
+func (o *Type) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var myArrayOfSimpleTypes ArrayOfSimpleTypes
+		if err := json.Unmarshal(bytes, &myArrayOfSimpleTypes); err != nil {
+			return err
+		}
+		*o = Type{ArrayOfSimpleTypes: &myArrayOfSimpleTypes}
 		return nil
 	}
-	return errors.New("failed to unmarshal any of the object properties")
+	var mySimpleTypes SimpleTypes
+	if err := json.Unmarshal(bytes, &mySimpleTypes); err != nil {
+		return err
+	}
+	*o = Type{SimpleTypes: &mySimpleTypes}
+	return nil
 }
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
 func (o Type) MarshalJSON() ([]byte, error) {
-	out := []interface{}{}
 	if o.SimpleTypes != nil {
-		out = append(out, o.SimpleTypes)
+		return json.Marshal(o.SimpleTypes)
 	}
 	if o.ArrayOfSimpleTypes != nil {
-		out = append(out, o.ArrayOfSimpleTypes)
+		return json.Marshal(o.ArrayOfSimpleTypes)
 	}
-	return json.Marshal(out)
+	return nil, errors.New("failed to marshal any one of the object properties")
 }
 type Format string
 type ContentMediaType string
@@ -372,7 +508,7 @@ type JSONSchemaObject struct {
 	PatternProperties    *PatternProperties             `json:"patternProperties,omitempty"`
 	Dependencies         *Dependencies                  `json:"dependencies,omitempty"`
 	PropertyNames        *JSONSchema                    `json:"propertyNames,omitempty"`
-	Const                *AlwaysTrue                    `json:"const,omitempty"`
+	Const                json.RawMessage                `json:"const,omitempty"`
 	Enum                 *Enum                          `json:"enum,omitempty"`
 	Type                 *Type                          `json:"type,omitempty"`
 	Format               *Format                        `json:"format,omitempty"`
@@ -433,72 +569,46 @@ type ContentDescriptorObject struct {
 	Schema      *ContentDescriptorObjectSchema      `json:"schema"`
 	Required    *ContentDescriptorObjectRequired    `json:"required,omitempty"`
 	Deprecated  *ContentDescriptorObjectDeprecated  `json:"deprecated,omitempty"`
-}
-type ContentDescriptorOrReference struct {
-	ContentDescriptorObject *ContentDescriptorObject
-	ReferenceObject         *ReferenceObject
+	Extensions  map[string]json.RawMessage          `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ContentDescriptorOrReference) UnmarshalJSON(bytes []byte) error {
-	var myContentDescriptorObject ContentDescriptorObject
-	if err := json.Unmarshal(bytes, &myContentDescriptorObject); err == nil {
-		o.ContentDescriptorObject = &myContentDescriptorObject
-		return nil
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ContentDescriptorObject) UnmarshalJSON(bytes []byte) error {
+	type plain ContentDescriptorObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = ContentDescriptorObject(p)
+	o.Extensions = extensions
+	return nil
 }
-func (o ContentDescriptorOrReference) MarshalJSON() ([]byte, error) {
-	if o.ContentDescriptorObject != nil {
-		return json.Marshal(o.ContentDescriptorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ContentDescriptorObject) MarshalJSON() ([]byte, error) {
+	type plain ContentDescriptorObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
+type ContentDescriptorOrReference = OrRef[ContentDescriptorObject]
 //  A list of parameters that are applicable for this method. The list MUST NOT include duplicated parameters and therefore require [name](#content-descriptor-name) to be unique. The list can use the [Reference Object](#reference-object) to link to parameters that are defined by the [Content Descriptor Object](#content-descriptor-object). All optional params (content descriptor objects with "required": false) MUST be positioned after all required params in the list.
 type MethodObjectParams []ContentDescriptorOrReference
 // The description of the result returned by the method. If defined, it MUST be a Content Descriptor or Reference Object. If undefined, the method MUST only be used as a [notification](https://www.jsonrpc.org/specification#notification)
-type MethodObjectResult struct {
-	ContentDescriptorObject *ContentDescriptorObject
-	ReferenceObject         *ReferenceObject
-}
+type MethodObjectResult = OrRef[ContentDescriptorObject]
+// A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.
+type ErrorObjectCode int64
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *MethodObjectResult) UnmarshalJSON(bytes []byte) error {
-	var myContentDescriptorObject ContentDescriptorObject
-	if err := json.Unmarshal(bytes, &myContentDescriptorObject); err == nil {
-		o.ContentDescriptorObject = &myContentDescriptorObject
-		return nil
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *ErrorObjectCode) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o MethodObjectResult) MarshalJSON() ([]byte, error) {
-	if o.ContentDescriptorObject != nil {
-		return json.Marshal(o.ContentDescriptorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+	*o = ErrorObjectCode(i)
+	return nil
 }
-// A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.
-type ErrorObjectCode int64
 // A String providing a short description of the error. The message SHOULD be limited to a concise single sentence.
 type ErrorObjectMessage string
 // A Primitive or Structured value that contains additional information about the error. This may be omitted. The value of this member is defined by the Server (e.g. detailed error information, nested errors etc.).
@@ -509,40 +619,11 @@ type ErrorObject struct {
 	Message *ErrorObjectMessage `json:"message"`
 	Data    *ErrorObjectData    `json:"data,omitempty"`
 }
-type ErrorOrReference struct {
-	ErrorObject     *ErrorObject
-	ReferenceObject *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ErrorOrReference) UnmarshalJSON(bytes []byte) error {
-	var myErrorObject ErrorObject
-	if err := json.Unmarshal(bytes, &myErrorObject); err == nil {
-		o.ErrorObject = &myErrorObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ErrorOrReference) MarshalJSON() ([]byte, error) {
-	if o.ErrorObject != nil {
-		return json.Marshal(o.ErrorObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ErrorOrReference = OrRef[ErrorObject]
 // A list of custom application defined errors that MAY be returned. The Errors MUST have unique error codes.
 type MethodObjectErrors []ErrorOrReference
 // Cannonical name of the link.
-type LinkObjectName interface{}
+type LinkObjectName string
 // Short description for the link.
 type LinkObjectSummary string
 // The name of an existing, resolvable OpenRPC method, as defined with a unique `method`. This field MUST resolve to a unique [Method Object](#method-object). As opposed to Open Api, Relative `method` values ARE NOT permitted.
@@ -550,47 +631,71 @@ type LinkObjectMethod string
 // A description of the link. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.
 type LinkObjectDescription string
 // A map representing parameters to pass to a method as specified with `method`. The key is the parameter name to be used, whereas the value can be a constant or a [runtime expression](#runtime-expression) to be evaluated and passed to the linked method.
-type LinkObjectParams interface{}
+type LinkObjectParams map[string]RuntimeExpression
+// A constant or a [runtime expression](#runtime-expression) evaluated and passed to the linked method.
+type RuntimeExpression interface{}
 // A server object to be used by the target method.
 type LinkObjectServer struct {
-	Url         *ServerObjectUrl         `json:"url"`
-	Name        *ServerObjectName        `json:"name,omitempty"`
-	Description *ServerObjectDescription `json:"description,omitempty"`
-	Summary     *ServerObjectSummary     `json:"summary,omitempty"`
-	Variables   *ServerObjectVariables   `json:"variables,omitempty"`
-}
-// A object representing a Link
-type LinkObject interface{}
-type LinkOrReference struct {
-	LinkObject      *LinkObject
-	ReferenceObject *ReferenceObject
+	Url         *ServerObjectUrl           `json:"url"`
+	Name        *ServerObjectName          `json:"name,omitempty"`
+	Description *ServerObjectDescription   `json:"description,omitempty"`
+	Summary     *ServerObjectSummary       `json:"summary,omitempty"`
+	Variables   *ServerObjectVariables     `json:"variables,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *LinkOrReference) UnmarshalJSON(bytes []byte) error {
-	var myLinkObject LinkObject
-	if err := json.Unmarshal(bytes, &myLinkObject); err == nil {
-		o.LinkObject = &myLinkObject
-		return nil
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LinkObjectServer) UnmarshalJSON(bytes []byte) error {
+	type plain LinkObjectServer
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = LinkObjectServer(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LinkObjectServer) MarshalJSON() ([]byte, error) {
+	type plain LinkObjectServer
+	return marshalExtensions(plain(o), o.Extensions)
 }
-func (o LinkOrReference) MarshalJSON() ([]byte, error) {
-	if o.LinkObject != nil {
-		return json.Marshal(o.LinkObject)
+// A object representing a Link
+type LinkObject struct {
+	Name        *LinkObjectName            `json:"name,omitempty"`
+	Summary     *LinkObjectSummary         `json:"summary,omitempty"`
+	Method      *LinkObjectMethod          `json:"method,omitempty"`
+	Description *LinkObjectDescription     `json:"description,omitempty"`
+	Params      *LinkObjectParams          `json:"params,omitempty"`
+	Server      *LinkObjectServer          `json:"server,omitempty"`
+	Extensions  map[string]json.RawMessage `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *LinkObject) UnmarshalJSON(bytes []byte) error {
+	type plain LinkObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+	*o = LinkObject(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o LinkObject) MarshalJSON() ([]byte, error) {
+	type plain LinkObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
+type LinkOrReference = OrRef[LinkObject]
 // A list of possible links from this method call.
 type MethodObjectLinks []LinkOrReference
 // Name for the example pairing.
@@ -607,74 +712,38 @@ type ExampleObjectDescription string
 type ExampleObjectName string
 // The Example object is an object that defines an example that is intended to match the `schema` of a given [Content Descriptor](#content-descriptor-object).
 type ExampleObject struct {
-	Summary     *ExampleObjectSummary     `json:"summary,omitempty"`
-	Value       *ExampleObjectValue       `json:"value"`
-	Description *ExampleObjectDescription `json:"description,omitempty"`
-	Name        *ExampleObjectName        `json:"name"`
-}
-type ExampleOrReference struct {
-	ExampleObject   *ExampleObject
-	ReferenceObject *ReferenceObject
+	Summary     *ExampleObjectSummary      `json:"summary,omitempty"`
+	Value       *ExampleObjectValue        `json:"value"`
+	Description *ExampleObjectDescription  `json:"description,omitempty"`
+	Name        *ExampleObjectName         `json:"name"`
+	Extensions  map[string]json.RawMessage `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExampleOrReference) UnmarshalJSON(bytes []byte) error {
-	var myExampleObject ExampleObject
-	if err := json.Unmarshal(bytes, &myExampleObject); err == nil {
-		o.ExampleObject = &myExampleObject
-		return nil
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *ExampleObject) UnmarshalJSON(bytes []byte) error {
+	type plain ExampleObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = ExampleObject(p)
+	o.Extensions = extensions
+	return nil
 }
-func (o ExampleOrReference) MarshalJSON() ([]byte, error) {
-	if o.ExampleObject != nil {
-		return json.Marshal(o.ExampleObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ExampleObject) MarshalJSON() ([]byte, error) {
+	type plain ExampleObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
+type ExampleOrReference = OrRef[ExampleObject]
 // Example parameters.
 type ExamplePairingObjectParams []ExampleOrReference
 // Example result. When not provided, the example pairing represents usage of the method as a notification.
-type ExamplePairingObjectResult struct {
-	ExampleObject   *ExampleObject
-	ReferenceObject *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExamplePairingObjectResult) UnmarshalJSON(bytes []byte) error {
-	var myExampleObject ExampleObject
-	if err := json.Unmarshal(bytes, &myExampleObject); err == nil {
-		o.ExampleObject = &myExampleObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ExamplePairingObjectResult) MarshalJSON() ([]byte, error) {
-	if o.ExampleObject != nil {
-		return json.Marshal(o.ExampleObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ExamplePairingObjectResult = OrRef[ExampleObject]
 // The Example Pairing object consists of a set of example params and result. The result is what you can expect from the JSON-RPC service given the exact params.
 type ExamplePairingObject struct {
 	Name        *ExamplePairingObjectName        `json:"name"`
@@ -682,36 +751,7 @@ type ExamplePairingObject struct {
 	Params      *ExamplePairingObjectParams      `json:"params"`
 	Result      *ExamplePairingObjectResult      `json:"result,omitempty"`
 }
-type ExamplePairingOrReference struct {
-	ExamplePairingObject *ExamplePairingObject
-	ReferenceObject      *ReferenceObject
-}
-// UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *ExamplePairingOrReference) UnmarshalJSON(bytes []byte) error {
-	var myExamplePairingObject ExamplePairingObject
-	if err := json.Unmarshal(bytes, &myExamplePairingObject); err == nil {
-		o.ExamplePairingObject = &myExamplePairingObject
-		return nil
-	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
-	}
-	return errors.New("failed to unmarshal one of the object properties")
-}
-func (o ExamplePairingOrReference) MarshalJSON() ([]byte, error) {
-	if o.ExamplePairingObject != nil {
-		return json.Marshal(o.ExamplePairingObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
-}
+type ExamplePairingOrReference = OrRef[ExamplePairingObject]
 // Array of [Example Pairing Objects](#example-pairing-object) where each example includes a valid params-to-result [Content Descriptor](#content-descriptor-object) pairing.
 type MethodObjectExamples []ExamplePairingOrReference
 // Declares this method to be deprecated. Consumers SHOULD refrain from usage of the declared method. Default value is `false`.
@@ -731,53 +771,46 @@ type MethodObject struct {
 	Examples       *MethodObjectExamples        `json:"examples,omitempty"`
 	Deprecated     *MethodObjectDeprecated      `json:"deprecated,omitempty"`
 	ExternalDocs   *ExternalDocumentationObject `json:"externalDocs,omitempty"`
-}
-type MethodOrReference struct {
-	MethodObject    *MethodObject
-	ReferenceObject *ReferenceObject
+	Extensions     map[string]json.RawMessage   `json:"-"`
 }
 // UnmarshalJSON implements the json Unmarshaler interface.
-// This implementation DOES NOT assert that ONE AND ONLY ONE
-// of the simple properties is satisfied; it lazily uses the first one that is satisfied.
-// Ergo, it will not return an error if more than one property is valid.
-func (o *MethodOrReference) UnmarshalJSON(bytes []byte) error {
-	var myMethodObject MethodObject
-	if err := json.Unmarshal(bytes, &myMethodObject); err == nil {
-		o.MethodObject = &myMethodObject
-		return nil
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *MethodObject) UnmarshalJSON(bytes []byte) error {
+	type plain MethodObject
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
 	}
-	var myReferenceObject ReferenceObject
-	if err := json.Unmarshal(bytes, &myReferenceObject); err == nil {
-		o.ReferenceObject = &myReferenceObject
-		return nil
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
 	}
-	return errors.New("failed to unmarshal one of the object properties")
+	*o = MethodObject(p)
+	o.Extensions = extensions
+	return nil
 }
-func (o MethodOrReference) MarshalJSON() ([]byte, error) {
-	if o.MethodObject != nil {
-		return json.Marshal(o.MethodObject)
-	}
-	if o.ReferenceObject != nil {
-		return json.Marshal(o.ReferenceObject)
-	}
-	return nil, errors.New("failed to marshal any one of the object properties")
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o MethodObject) MarshalJSON() ([]byte, error) {
+	type plain MethodObject
+	return marshalExtensions(plain(o), o.Extensions)
 }
+type MethodOrReference = OrRef[MethodObject]
 // The available methods for the API. While it is required, the array may be empty (to handle security filtering, for example).
 type Methods []MethodOrReference
 // An object to hold reusable [Schema Objects](#schema-object).
-type SchemaComponents map[string]interface{}
+type SchemaComponents map[string]JSONSchema
 // An object to hold reusable [Link Objects](#link-object).
-type LinkComponents map[string]interface{}
+type LinkComponents map[string]LinkObject
 // An object to hold reusable [Error Objects](#error-object).
-type ErrorComponents map[string]interface{}
+type ErrorComponents map[string]ErrorObject
 // An object to hold reusable [Example Objects](#example-object).
-type ExampleComponents map[string]interface{}
+type ExampleComponents map[string]ExampleObject
 // An object to hold reusable [Example Pairing Objects](#example-pairing-object).
-type ExamplePairingComponents map[string]interface{}
+type ExamplePairingComponents map[string]ExamplePairingObject
 // An object to hold reusable [Content Descriptor Objects](#content-descriptor-object).
-type ContentDescriptorComponents map[string]interface{}
+type ContentDescriptorComponents map[string]ContentDescriptorObject
 // An object to hold reusable [Tag Objects](#tag-object).
-type TagComponents map[string]interface{}
+type TagComponents map[string]TagObject
 // Holds a set of reusable objects for different aspects of the OpenRPC. All objects defined within the components object will have no effect on the API unless they are explicitly referenced from properties outside the components object.
 type Components struct {
 	Schemas            *SchemaComponents            `json:"schemas,omitempty"`
@@ -802,6 +835,198 @@ type OpenrpcDocument struct {
 	Methods      *Methods                     `json:"methods"`
 	Components   *Components                  `json:"components,omitempty"`
 	Schema       *MetaSchema                  `json:"$schema,omitempty"`
+	Extensions   map[string]json.RawMessage   `json:"-"`
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *OpenrpcDocument) UnmarshalJSON(bytes []byte) error {
+	type plain OpenrpcDocument
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = OpenrpcDocument(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o OpenrpcDocument) MarshalJSON() ([]byte, error) {
+	type plain OpenrpcDocument
+	return marshalExtensions(plain(o), o.Extensions)
+}
+// OrRef holds either a T or a Reference Object pointing at one.
+// When unmarshalling, the variant is chosen by the presence of "$ref": a Reference Object
+// carries it and nothing else, while a T never does and has at least one of its fields.
+// Any other input is rejected.
+type OrRef[T any] struct {
+	value     *T
+	reference *ReferenceObject
+}
+// NewValue returns an OrRef holding v.
+func NewValue[T any](v T) OrRef[T] {
+	return OrRef[T]{value: &v}
+}
+// NewRef returns an OrRef holding a Reference Object to ref.
+func NewRef[T any](ref string) OrRef[T] {
+	r := Ref(ref)
+	return OrRef[T]{reference: &ReferenceObject{Ref: &r}}
+}
+// IsRef reports whether o holds a Reference Object.
+func (o OrRef[T]) IsRef() bool {
+	return o.reference != nil
+}
+// Value returns the T held by o, or nil when o holds a Reference Object.
+func (o OrRef[T]) Value() *T {
+	return o.value
+}
+// Ref returns the Reference Object held by o, or nil when o holds a T.
+func (o OrRef[T]) Ref() *ReferenceObject {
+	return o.reference
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (o *OrRef[T]) UnmarshalJSON(bytes []byte) error {
+	isReference, err := isReferenceObject(bytes, jsonFields(new(T)))
+	if err != nil {
+		return err
+	}
+	if isReference {
+		var myReferenceObject ReferenceObject
+		if err := json.Unmarshal(bytes, &myReferenceObject); err != nil {
+			return err
+		}
+		*o = OrRef[T]{reference: &myReferenceObject}
+		return nil
+	}
+	var myValue T
+	if err := json.Unmarshal(bytes, &myValue); err != nil {
+		return err
+	}
+	*o = OrRef[T]{value: &myValue}
+	return nil
+}
+func (o OrRef[T]) MarshalJSON() ([]byte, error) {
+	if o.value != nil {
+		return json.Marshal(o.value)
+	}
+	if o.reference != nil {
+		return json.Marshal(o.reference)
+	}
+	return nil, errors.New("failed to marshal any one of the object properties")
+}
+// jsonFields returns the JSON names of the fields of the T an OrRef holds, or nil when T is
+// not one of the generated objects.
+func jsonFields(v any) []string {
+	switch v.(type) {
+	case *TagObject:
+		return []string{"name", "description", "externalDocs"}
+	case *ContentDescriptorObject:
+		return []string{"name", "description", "summary", "schema", "required", "deprecated"}
+	case *ErrorObject:
+		return []string{"code", "message", "data"}
+	case *LinkObject:
+		return []string{"name", "summary", "method", "description", "params", "server"}
+	case *ExampleObject:
+		return []string{"summary", "value", "description", "name"}
+	case *ExamplePairingObject:
+		return []string{"name", "description", "params", "result"}
+	case *MethodObject:
+		return []string{"name", "description", "summary", "servers", "tags", "paramStructure", "params", "result", "errors", "links", "examples", "deprecated", "externalDocs"}
+	}
+	return nil
+}
+// isReferenceObject reports whether a JSON object is a Reference Object, i.e. has a "$ref" field.
+// Objects mixing "$ref" with other fields are ambiguous and rejected, as are objects without any
+// of the given field names of the other variant and anything but an object. Nil names accept
+// any object without "$ref".
+func isReferenceObject(bytes []byte, names []string) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil || fields == nil {
+		return false, errors.New("expected a JSON object or a Reference Object")
+	}
+	if _, ok := fields["$ref"]; !ok {
+		if names == nil {
+			return false, nil
+		}
+		for _, name := range names {
+			if _, ok := fields[name]; ok {
+				return false, nil
+			}
+		}
+		return false, errors.New("object matches neither variant: no known field and no $ref")
+	}
+	if len(fields) > 1 {
+		return false, errors.New("ambiguous object: $ref cannot be combined with other fields")
+	}
+	return true, nil
+}
+// isJSONArray reports whether a JSON value is an array.
+func isJSONArray(bytes []byte) bool {
+	return strings.HasPrefix(strings.TrimLeft(string(bytes), " \t\r\n"), "[")
+}
+// unmarshalExtensions collects the ^x- specification extension fields of a JSON object.
+func unmarshalExtensions(bytes []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	var extensions map[string]json.RawMessage
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]json.RawMessage{}
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+// marshalExtensions marshals v and appends the ^x- specification extension fields, sorted by key.
+func marshalExtensions(v interface{}, extensions map[string]json.RawMessage) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return bytes, err
+	}
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	out := append([]byte{}, bytes[:len(bytes)-1]...)
+	for _, key := range keys {
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, name...)
+		out = append(out, ':')
+		out = append(out, extensions[key]...)
+	}
+	return append(out, '}'), nil
+}
+// decodeInteger decodes a JSON number that has no fractional part, however it is written.
+func decodeInteger(bytes []byte) (int64, error) {
+	var n json.Number
+	if strings.HasPrefix(string(bytes), `"`) || json.Unmarshal(bytes, &n) != nil {
+		return 0, errors.New("json: cannot unmarshal " + string(bytes) + " into an integer")
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errors.New("json: cannot unmarshal " + string(n) + " into an integer")
+	}
+	return int64(f), nil
 }
 
 const RawOpenrpcDocument = "{\"$schema\":\"https://meta.json-schema.tools/\",\"$id\":\"https://meta.open-rpc.org/\",\"title\":\"openrpcDocument\",\"type\":\"object\",\"required\":[\"info\",\"methods\",\"openrpc\"],\"additionalProperties\":false,\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}},\"properties\":{\"openrpc\":{\"description\":\"This string MUST be the [semantic version number](https://semver.org/spec/v2.0.0.html) of the [OpenRPC Specification version](#versions) that the OpenRPC document uses. The `openrpc` field SHOULD be used by tooling specifications and clients to interpret the OpenRPC document. This is *not* related to the API [`info.version`](#info-version) string.\",\"title\":\"openrpc\",\"type\":\"string\",\"regex\":\"^1\\\\.4\\\\.\\\\d+$\"},\"info\":{\"$ref\":\"#/definitions/infoObject\"},\"externalDocs\":{\"$ref\":\"#/definitions/externalDocumentationObject\"},\"servers\":{\"description\":\"An array of Server Objects, which provide connectivity information to a target server. If the `servers` property is not provided, or is an empty array, the default value would be a [Server Object](#server-object) with a [url](#server-url) value of `localhost`. \",\"title\":\"servers\",\"type\":\"array\",\"additionalItems\":false,\"items\":{\"$ref\":\"#/definitions/serverObject\"}},\"methods\":{\"title\":\"methods\",\"type\":\"array\",\"description\":\"The available methods for the API. While it is required, the array may be empty (to handle security filtering, for example).\",\"additionalItems\":false,\"items\":{\"title\":\"methodOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/methodObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"components\":{\"title\":\"components\",\"description\":\"Holds a set of reusable objects for different aspects of the OpenRPC. All objects defined within the components object will have no effect on the API unless they are explicitly referenced from properties outside the components object.\",\"type\":\"object\",\"properties\":{\"schemas\":{\"title\":\"schemaComponents\",\"description\":\"An object to hold reusable [Schema Objects](#schema-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/JSONSchema\"}}},\"links\":{\"title\":\"linkComponents\",\"type\":\"object\",\"description\":\"An object to hold reusable [Link Objects](#link-object).\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/linkObject\"}}},\"errors\":{\"title\":\"errorComponents\",\"description\":\"An object to hold reusable [Error Objects](#error-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/errorObject\"}}},\"examples\":{\"title\":\"exampleComponents\",\"description\":\"An object to hold reusable [Example Objects](#example-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/exampleObject\"}}},\"examplePairings\":{\"title\":\"examplePairingComponents\",\"description\":\"An object to hold reusable [Example Pairing Objects](#example-pairing-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/examplePairingObject\"}}},\"contentDescriptors\":{\"title\":\"contentDescriptorComponents\",\"description\":\"An object to hold reusable [Content Descriptor Objects](#content-descriptor-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/contentDescriptorObject\"}}},\"tags\":{\"title\":\"tagComponents\",\"description\":\"An object to hold reusable [Tag Objects](#tag-object).\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"$ref\":\"#/definitions/tagObject\"}}}}},\"$schema\":{\"title\":\"metaSchema\",\"description\":\"JSON Schema URI (used by some editors)\",\"type\":\"string\",\"default\":\"https://meta.open-rpc.org/\"}},\"definitions\":{\"specificationExtension\":{\"title\":\"specificationExtension\",\"description\":\"This object MAY be extended with [Specification Extensions](#specification-extensions).\"},\"JSONSchema\":{\"$ref\":\"https://meta.json-schema.tools\"},\"referenceObject\":{\"title\":\"referenceObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"$ref\"],\"properties\":{\"$ref\":{\"description\":\"The reference string.\",\"$ref\":\"https://meta.json-schema.tools/#/definitions/JSONSchemaObject/properties/$ref\"}}},\"errorObject\":{\"title\":\"errorObject\",\"type\":\"object\",\"description\":\"Defines an application level error.\",\"additionalProperties\":false,\"required\":[\"code\",\"message\"],\"properties\":{\"code\":{\"title\":\"errorObjectCode\",\"description\":\"A Number that indicates the error type that occurred. This MUST be an integer. The error codes from and including -32768 to -32000 are reserved for pre-defined errors. These pre-defined errors SHOULD be assumed to be returned from any JSON-RPC api.\",\"type\":\"integer\"},\"message\":{\"title\":\"errorObjectMessage\",\"description\":\"A String providing a short description of the error. The message SHOULD be limited to a concise single sentence.\",\"type\":\"string\"},\"data\":{\"title\":\"errorObjectData\",\"description\":\"A Primitive or Structured value that contains additional information about the error. This may be omitted. The value of this member is defined by the Server (e.g. detailed error information, nested errors etc.).\"}}},\"licenseObject\":{\"title\":\"licenseObject\",\"description\":\"License information for the exposed API.\",\"type\":\"object\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"licenseObjectName\",\"description\":\"The license name used for the API.\",\"type\":\"string\"},\"url\":{\"title\":\"licenseObjectUrl\",\"description\":\"A URL to the license used for the API. MUST be in the format of a URL.\",\"type\":\"string\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"contactObject\":{\"description\":\"Contact information for the exposed API.\",\"title\":\"contactObject\",\"type\":\"object\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"contactObjectName\",\"description\":\"The identifying name of the contact person/organization.\",\"type\":\"string\"},\"email\":{\"title\":\"contactObjectEmail\",\"description\":\"The email address of the contact person/organization. MUST be in the format of an email address.\",\"type\":\"string\"},\"url\":{\"title\":\"contactObjectUrl\",\"description\":\"The URL pointing to the contact information. MUST be in the format of a URL.\",\"type\":\"string\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"infoObject\":{\"title\":\"infoObject\",\"description\":\"The object provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.\",\"additionalProperties\":false,\"required\":[\"title\",\"version\"],\"properties\":{\"title\":{\"title\":\"infoObjectTitle\",\"description\":\"The title of the application.\",\"type\":\"string\"},\"description\":{\"title\":\"infoObjectDescription\",\"description\":\"A verbose description of the application. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"termsOfService\":{\"title\":\"infoObjectTermsOfService\",\"description\":\"A URL to the Terms of Service for the API. MUST be in the format of a URL.\",\"type\":\"string\",\"format\":\"uri\"},\"version\":{\"title\":\"infoObjectVersion\",\"description\":\"The version of the OpenRPC document (which is distinct from the [OpenRPC Specification version](#openrpc-version) or the API implementation version).\",\"type\":\"string\"},\"contact\":{\"$ref\":\"#/definitions/contactObject\"},\"license\":{\"$ref\":\"#/definitions/licenseObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"serverObject\":{\"title\":\"serverObject\",\"description\":\"A object representing a Server\",\"type\":\"object\",\"required\":[\"url\"],\"additionalProperties\":false,\"properties\":{\"url\":{\"title\":\"serverObjectUrl\",\"description\":\"A URL to the target host. This URL supports Server Variables and MAY be relative, to indicate that the host location is relative to the location where the OpenRPC document is being served. [Server Variables](#server-variables) are passed into the [Runtime Expression](#runtime-expression) to produce a server URL.\",\"type\":\"string\",\"format\":\"uri\"},\"name\":{\"title\":\"serverObjectName\",\"description\":\"An optional string describing the name of the server. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"description\":{\"title\":\"serverObjectDescription\",\"description\":\"An optional string describing the host designated by the URL. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"summary\":{\"title\":\"serverObjectSummary\",\"description\":\"A short summary of what the server is.\",\"type\":\"string\"},\"variables\":{\"title\":\"serverObjectVariables\",\"description\":\"A map between a variable name and its value. The value is passed into the [Runtime Expression](#runtime-expression) to produce a server URL.\",\"type\":\"object\",\"patternProperties\":{\"[0-z]+\":{\"title\":\"serverObjectVariable\",\"description\":\"An object representing a Server Variable for server URL template substitution.\",\"type\":\"object\",\"required\":[\"default\"],\"properties\":{\"default\":{\"title\":\"serverObjectVariableDefault\",\"description\":\"The default value to use for substitution, which SHALL be sent if an alternate value is _not_ supplied. Note this behavior is different than the [Schema Object's](#schema-object) treatment of default values, because in those cases parameter values are optional.\",\"type\":\"string\"},\"description\":{\"title\":\"serverObjectVariableDescription\",\"description\":\"An optional description for the server variable. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"enum\":{\"title\":\"serverObjectVariableEnum\",\"description\":\"An enumeration of string values to be used if the substitution options are from a limited set.\",\"type\":\"array\",\"items\":{\"title\":\"serverObjectVariableEnumItem\",\"description\":\"An enumeration of string values to be used if the substitution options are from a limited set.\",\"type\":\"string\"}}}}}}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"linkObject\":{\"title\":\"linkObject\",\"description\":\"A object representing a Link\",\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"linkObjectName\",\"description\":\"Cannonical name of the link.\",\"minLength\":1},\"summary\":{\"title\":\"linkObjectSummary\",\"description\":\"Short description for the link.\",\"type\":\"string\"},\"method\":{\"title\":\"linkObjectMethod\",\"description\":\"The name of an existing, resolvable OpenRPC method, as defined with a unique `method`. This field MUST resolve to a unique [Method Object](#method-object). As opposed to Open Api, Relative `method` values ARE NOT permitted.\",\"type\":\"string\"},\"description\":{\"title\":\"linkObjectDescription\",\"description\":\"A description of the link. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"params\":{\"title\":\"linkObjectParams\",\"description\":\"A map representing parameters to pass to a method as specified with `method`. The key is the parameter name to be used, whereas the value can be a constant or a [runtime expression](#runtime-expression) to be evaluated and passed to the linked method.\"},\"server\":{\"title\":\"linkObjectServer\",\"description\":\"A server object to be used by the target method.\",\"$ref\":\"#/definitions/serverObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"externalDocumentationObject\":{\"description\":\"Additional external documentation.\",\"title\":\"externalDocumentationObject\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"url\"],\"properties\":{\"description\":{\"description\":\"A verbose explanation of the documentation. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"title\":\"externalDocumentationObjectDescription\",\"type\":\"string\"},\"url\":{\"description\":\"The URL for the target documentation. Value MUST be in the format of a URL.\",\"title\":\"externalDocumentationObjectUrl\",\"type\":\"string\",\"format\":\"uri\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"methodObject\":{\"title\":\"methodObject\",\"description\":\"Describes the interface for the given method name. The method name is used as the `method` field of the JSON-RPC body. It therefore MUST be unique.\",\"type\":\"object\",\"required\":[\"name\",\"params\"],\"additionalProperties\":false,\"properties\":{\"name\":{\"title\":\"methodObjectName\",\"description\":\"The cannonical name for the method. The name MUST be unique within the methods array.\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"methodObjectDescription\",\"description\":\"A verbose explanation of the method behavior. GitHub Flavored Markdown syntax MAY be used for rich text representation.\",\"type\":\"string\"},\"summary\":{\"title\":\"methodObjectSummary\",\"description\":\"A short summary of what the method does.\",\"type\":\"string\"},\"servers\":{\"title\":\"servers\",\"type\":\"array\",\"description\":\"An array of Server Objects, which provide connectivity information to a target server. If the `servers` property is not provided, or is an empty array, the default value would be a [Server Object](#server-object) with a [url](#server-url) value of `localhost`. \",\"additionalItems\":false,\"items\":{\"$ref\":\"#/definitions/serverObject\"}},\"tags\":{\"title\":\"methodObjectTags\",\"description\":\"A list of tags for API documentation control. Tags can be used for logical grouping of methods by resources or any other qualifier.\",\"type\":\"array\",\"items\":{\"title\":\"tagOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/tagObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"paramStructure\":{\"title\":\"methodObjectParamStructure\",\"type\":\"string\",\"description\":\"Format the server expects the params. Defaults to 'either'.\",\"enum\":[\"by-position\",\"by-name\",\"either\"],\"default\":\"either\"},\"params\":{\"title\":\"methodObjectParams\",\"description\":\" A list of parameters that are applicable for this method. The list MUST NOT include duplicated parameters and therefore require [name](#content-descriptor-name) to be unique. The list can use the [Reference Object](#reference-object) to link to parameters that are defined by the [Content Descriptor Object](#content-descriptor-object). All optional params (content descriptor objects with \\\"required\\\": false) MUST be positioned after all required params in the list.\",\"type\":\"array\",\"items\":{\"title\":\"contentDescriptorOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/contentDescriptorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"result\":{\"title\":\"methodObjectResult\",\"description\":\"The description of the result returned by the method. If defined, it MUST be a Content Descriptor or Reference Object. If undefined, the method MUST only be used as a [notification](https://www.jsonrpc.org/specification#notification)\",\"oneOf\":[{\"$ref\":\"#/definitions/contentDescriptorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]},\"errors\":{\"title\":\"methodObjectErrors\",\"description\":\"A list of custom application defined errors that MAY be returned. The Errors MUST have unique error codes.\",\"type\":\"array\",\"items\":{\"title\":\"errorOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/errorObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"links\":{\"title\":\"methodObjectLinks\",\"description\":\"A list of possible links from this method call.\",\"type\":\"array\",\"items\":{\"title\":\"linkOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/linkObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"examples\":{\"title\":\"methodObjectExamples\",\"description\":\"Array of [Example Pairing Objects](#example-pairing-object) where each example includes a valid params-to-result [Content Descriptor](#content-descriptor-object) pairing.\",\"type\":\"array\",\"items\":{\"title\":\"examplePairingOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/examplePairingObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"deprecated\":{\"title\":\"methodObjectDeprecated\",\"description\":\"Declares this method to be deprecated. Consumers SHOULD refrain from usage of the declared method. Default value is `false`.\",\"type\":\"boolean\",\"default\":false},\"externalDocs\":{\"$ref\":\"#/definitions/externalDocumentationObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"tagObject\":{\"title\":\"tagObject\",\"description\":\"Adds metadata to a single tag that is used by the [Method Object](#method-object). It is not mandatory to have a Tag Object per tag defined in the Method Object instances.\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"name\"],\"properties\":{\"name\":{\"title\":\"tagObjectName\",\"description\":\"The name of the tag.\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"tagObjectDescription\",\"description\":\"A verbose explanation for the tag. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"externalDocs\":{\"description\":\"Additional external documentation for this tag.\",\"$ref\":\"#/definitions/externalDocumentationObject\"}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"exampleObject\":{\"title\":\"exampleObject\",\"description\":\"The Example object is an object that defines an example that is intended to match the `schema` of a given [Content Descriptor](#content-descriptor-object).\",\"type\":\"object\",\"required\":[\"name\",\"value\"],\"properties\":{\"summary\":{\"title\":\"exampleObjectSummary\",\"description\":\"Short description for the example.\",\"type\":\"string\"},\"value\":{\"title\":\"exampleObjectValue\",\"description\":\"Embedded literal example. The `value` field and `externalValue` field are mutually exclusive. To represent examples of media types that cannot naturally represented in JSON, use a string value to contain the example, escaping where necessary.\"},\"description\":{\"title\":\"exampleObjectDescription\",\"description\":\"A verbose explanation of the example. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"name\":{\"title\":\"exampleObjectName\",\"description\":\"Cannonical name of the example.\",\"type\":\"string\",\"minLength\":1}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}},\"examplePairingObject\":{\"title\":\"examplePairingObject\",\"description\":\"The Example Pairing object consists of a set of example params and result. The result is what you can expect from the JSON-RPC service given the exact params.\",\"type\":\"object\",\"required\":[\"name\",\"params\"],\"properties\":{\"name\":{\"title\":\"examplePairingObjectName\",\"description\":\"Name for the example pairing.\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"examplePairingObjectDescription\",\"description\":\"A verbose explanation of the example pairing.\",\"type\":\"string\"},\"params\":{\"title\":\"examplePairingObjectParams\",\"description\":\"Example parameters.\",\"type\":\"array\",\"items\":{\"title\":\"exampleOrReference\",\"oneOf\":[{\"$ref\":\"#/definitions/exampleObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}},\"result\":{\"title\":\"examplePairingObjectResult\",\"description\":\"Example result. When not provided, the example pairing represents usage of the method as a notification.\",\"oneOf\":[{\"$ref\":\"#/definitions/exampleObject\"},{\"$ref\":\"#/definitions/referenceObject\"}]}}},\"contentDescriptorObject\":{\"title\":\"contentDescriptorObject\",\"description\":\"Content Descriptors are objects that do just as they suggest - describe content. They are reusable ways of describing either parameters or result. They MUST have a schema.\",\"type\":\"object\",\"additionalProperties\":false,\"required\":[\"name\",\"schema\"],\"properties\":{\"name\":{\"title\":\"contentDescriptorObjectName\",\"description\":\"Name of the content that is being described. If the content described is a method parameter assignable [`by-name`](#method-param-structure), this field SHALL define the parameter's key (ie name).\",\"type\":\"string\",\"minLength\":1},\"description\":{\"title\":\"contentDescriptorObjectDescription\",\"description\":\"A verbose explanation of the content descriptor behavior. [GitHub Flavored Markdown syntax](https://github.github.com/gfm/) MAY be used for rich text representation.\",\"type\":\"string\"},\"summary\":{\"title\":\"contentDescriptorObjectSummary\",\"description\":\"A short summary of the content that is being described.\",\"type\":\"string\"},\"schema\":{\"title\":\"contentDescriptorObjectSchema\",\"description\":\"Schema that describes the content.\",\"$ref\":\"#/definitions/JSONSchema\"},\"required\":{\"title\":\"contentDescriptorObjectRequired\",\"description\":\"Determines if the content is a required field. Default value is `false`.\",\"type\":\"boolean\",\"default\":false},\"deprecated\":{\"title\":\"contentDescriptorObjectDeprecated\",\"description\":\"Specifies that the content is deprecated and SHOULD be transitioned out of usage. Default value is `false`.\",\"type\":\"boolean\",\"default\":false}},\"patternProperties\":{\"^x-\":{\"$ref\":\"#/definitions/specificationExtension\"}}}}}"
@@ -0,0 +1,436 @@
+package v1_4
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// roundTrip decodes in into v and checks that encoding v gives in back, byte for byte
+// once in is compacted.
+func roundTrip(t *testing.T, in string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(in), v); err != nil {
+		t.Fatalf("unmarshal %s: %v", in, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", in, err)
+	}
+	var want bytes.Buffer
+	if err := json.Compact(&want, []byte(in)); err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != want.String() {
+		t.Errorf("round trip changed the document\n got: %s\nwant: %s", out, want.String())
+	}
+}
+
+func TestInfoObjectRoundTrip(t *testing.T) {
+	in := `{
+		"openrpc": "1.4.0",
+		"info": {
+			"title": "Petstore",
+			"description": "A sample API",
+			"termsOfService": "https://example.com/terms",
+			"version": "1.0.0",
+			"contact": {"name": "API Support", "email": "support@example.com", "url": "https://example.com/support"},
+			"license": {"name": "Apache 2.0", "url": "https://www.apache.org/licenses/LICENSE-2.0.html"}
+		},
+		"methods": []
+	}`
+	var doc OpenrpcDocument
+	roundTrip(t, in, &doc)
+
+	info := doc.Info
+	if info == nil {
+		t.Fatal("Info is nil")
+	}
+	if *info.Title != "Petstore" || *info.Version != "1.0.0" {
+		t.Errorf("Title, Version = %q, %q", *info.Title, *info.Version)
+	}
+	if *info.Description != "A sample API" || *info.TermsOfService != "https://example.com/terms" {
+		t.Errorf("Description, TermsOfService = %q, %q", *info.Description, *info.TermsOfService)
+	}
+	if *info.Contact.Name != "API Support" || *info.Contact.Email != "support@example.com" || *info.Contact.Url != "https://example.com/support" {
+		t.Errorf("Contact = %+v", *info.Contact)
+	}
+	if *info.License.Name != "Apache 2.0" || *info.License.Url != "https://www.apache.org/licenses/LICENSE-2.0.html" {
+		t.Errorf("License = %+v", *info.License)
+	}
+}
+
+func TestInfoObjectOmitsUnsetFields(t *testing.T) {
+	title, version := InfoObjectTitle("Minimal"), InfoObjectVersion("0.1.0")
+	out, err := json.Marshal(InfoObject{Title: &title, Version: &version})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"title":"Minimal","version":"0.1.0"}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestLinkObjectRoundTrip(t *testing.T) {
+	in := `[
+		{
+			"name": "getOwner",
+			"summary": "The pet's owner",
+			"method": "get_owner",
+			"description": "Looks up the owner of the pet.",
+			"params": {"petId": "$params.id", "verbose": true},
+			"server": {"url": "https://owners.example.com", "name": "owners"}
+		},
+		{"$ref": "#/components/links/getOwner"}
+	]`
+	var links MethodObjectLinks
+	roundTrip(t, in, &links)
+
+	if len(links) != 2 {
+		t.Fatalf("got %d links, want 2", len(links))
+	}
+	link := links[0].Value()
+	if link == nil {
+		t.Fatal("first link decoded as a reference")
+	}
+	if *link.Name != "getOwner" || *link.Method != "get_owner" || *link.Server.Url != "https://owners.example.com" {
+		t.Errorf("link = %+v", *link)
+	}
+	if params := *link.Params; params["petId"] != "$params.id" || params["verbose"] != true {
+		t.Errorf("Params = %v", params)
+	}
+	if !links[1].IsRef() || *links[1].Ref().Ref != "#/components/links/getOwner" {
+		t.Errorf("second link = %+v, want a reference", links[1])
+	}
+}
+
+func TestComponentsRoundTrip(t *testing.T) {
+	in := `{
+		"schemas": {"Any": true, "PetId": {"type": "integer"}},
+		"links": {"getOwner": {"method": "get_owner"}},
+		"errors": {"NotFound": {"code": 404, "message": "not found"}},
+		"examples": {"fido": {"value": "Fido", "name": "fido"}},
+		"examplePairings": {"getPet": {"name": "getPet", "params": [{"$ref": "#/components/examples/fido"}]}},
+		"contentDescriptors": {"petId": {"name": "petId", "schema": {"$ref": "#/components/schemas/PetId"}, "required": true}},
+		"tags": {"pets": {"name": "pets"}}
+	}`
+	var c Components
+	roundTrip(t, in, &c)
+
+	if s := (*c.Schemas)["PetId"]; s.JSONSchemaObject == nil || *s.JSONSchemaObject.Type.SimpleTypes != SimpleTypesEnum2 {
+		t.Errorf("schemas/PetId = %+v", s)
+	}
+	if s := (*c.Schemas)["Any"]; s.JSONSchemaBoolean == nil || !*s.JSONSchemaBoolean {
+		t.Errorf("schemas/Any = %+v", s)
+	}
+	if l := (*c.Links)["getOwner"]; *l.Method != "get_owner" {
+		t.Errorf("links/getOwner = %+v", l)
+	}
+	if e := (*c.Errors)["NotFound"]; *e.Code != 404 || *e.Message != "not found" {
+		t.Errorf("errors/NotFound = %+v", e)
+	}
+	if e := (*c.Examples)["fido"]; *e.Name != "fido" || *e.Value != "Fido" {
+		t.Errorf("examples/fido = %+v", e)
+	}
+	if p := (*c.ExamplePairings)["getPet"]; len(*p.Params) != 1 || !(*p.Params)[0].IsRef() {
+		t.Errorf("examplePairings/getPet = %+v", p)
+	}
+	if d := (*c.ContentDescriptors)["petId"]; *d.Name != "petId" || !*d.Required || *d.Schema.JSONSchemaObject.Ref != "#/components/schemas/PetId" {
+		t.Errorf("contentDescriptors/petId = %+v", d)
+	}
+	if tag := (*c.Tags)["pets"]; *tag.Name != "pets" {
+		t.Errorf("tags/pets = %+v", tag)
+	}
+}
+
+func TestNestedSchemaMapsRoundTrip(t *testing.T) {
+	in := `{
+		"definitions": {"Name": {"minLength": 1, "type": "string"}},
+		"properties": {
+			"owner": {"properties": {"name": {"$ref": "#/definitions/Name"}}},
+			"tags": {"items": {"type": "string"}}
+		},
+		"patternProperties": {"^x-": true},
+		"dependencies": {"owner": ["tags"], "tags": {"required": ["owner"]}}
+	}`
+	var s JSONSchemaObject
+	roundTrip(t, in, &s)
+
+	name := (*s.Definitions)["Name"].JSONSchemaObject
+	if name == nil || *name.MinLength != 1 {
+		t.Errorf("definitions/Name = %+v", (*s.Definitions)["Name"])
+	}
+	owner := (*s.Properties)["owner"].JSONSchemaObject
+	if ref := (*owner.Properties)["name"].JSONSchemaObject.Ref; *ref != "#/definitions/Name" {
+		t.Errorf("properties/owner/properties/name/$ref = %q", *ref)
+	}
+	if p := (*s.PatternProperties)["^x-"]; p.JSONSchemaBoolean == nil || !*p.JSONSchemaBoolean {
+		t.Errorf("patternProperties/^x- = %+v", p)
+	}
+	if d := (*s.Dependencies)["owner"]; d.StringArray == nil || (*d.StringArray)[0] != "tags" {
+		t.Errorf("dependencies/owner = %+v", d)
+	}
+	if d := (*s.Dependencies)["tags"]; d.JSONSchema == nil || (*d.JSONSchema.JSONSchemaObject.Required)[0] != "owner" {
+		t.Errorf("dependencies/tags = %+v", d)
+	}
+}
+
+func TestConstKeepsNull(t *testing.T) {
+	var s JSONSchemaObject
+	roundTrip(t, `{"const": null}`, &s)
+	if string(s.Const) != "null" {
+		t.Errorf("const = %q, want null", s.Const)
+	}
+	s = JSONSchemaObject{}
+	roundTrip(t, `{"type": "string"}`, &s)
+	if s.Const != nil {
+		t.Errorf("missing const = %q, want none", s.Const)
+	}
+}
+
+func TestServerObjectVariablesRoundTrip(t *testing.T) {
+	in := `{
+		"url": "https://{region}.example.com:{port}",
+		"variables": {
+			"port": {"default": "443"},
+			"region": {"default": "eu", "description": "The region to call.", "enum": ["eu", "us"]}
+		}
+	}`
+	for _, v := range []interface{}{&ServerObject{}, &LinkObjectServer{}} {
+		roundTrip(t, in, v)
+	}
+
+	var server ServerObject
+	if err := json.Unmarshal([]byte(in), &server); err != nil {
+		t.Fatal(err)
+	}
+	region := (*server.Variables)["region"]
+	if *region.Default != "eu" || *region.Description != "The region to call." {
+		t.Errorf("variables/region = %+v", region)
+	}
+	if enum := *region.Enum; len(enum) != 2 || enum[1] != "us" {
+		t.Errorf("variables/region/enum = %v", enum)
+	}
+}
+
+func TestExtensionsRoundTrip(t *testing.T) {
+	in := `{
+		"openrpc": "1.4.0",
+		"info": {
+			"title": "Petstore",
+			"version": "1.0.0",
+			"contact": {"name": "Support", "x-team": "pets"},
+			"license": {"name": "MIT", "x-spdx": "MIT"},
+			"x-logo": {"url": "https://example.com/logo.png"}
+		},
+		"externalDocs": {"url": "https://example.com/docs", "x-audience": "public"},
+		"servers": [{"url": "https://example.com", "x-region": "eu"}],
+		"methods": [
+			{
+				"name": "get_pet",
+				"tags": [{"name": "pets", "x-order": 1}],
+				"params": [{"name": "id", "schema": {"type": "integer"}, "x-example": 7}],
+				"result": {"name": "pet", "schema": true},
+				"links": [{"method": "get_owner", "server": {"url": "https://owners.example.com", "x-internal": true}, "x-link": "owner"}],
+				"examples": [{"name": "fido", "params": [{"value": 7, "name": "id", "x-generated": false}]}],
+				"x-auth": ["read"],
+				"x-rate-limit": {"requests": 10, "window": "1s"}
+			}
+		],
+		"x-a": null,
+		"x-z": "last"
+	}`
+	var doc OpenrpcDocument
+	roundTrip(t, in, &doc)
+
+	method := (*doc.Methods)[0].Value()
+	if got := string(method.Extensions["x-rate-limit"]); got != `{"requests": 10, "window": "1s"}` {
+		t.Errorf("x-rate-limit = %s", got)
+	}
+	if got := string(doc.Info.Contact.Extensions["x-team"]); got != `"pets"` {
+		t.Errorf("x-team = %s", got)
+	}
+	if got := string(doc.Extensions["x-a"]); got != "null" {
+		t.Errorf("x-a = %s", got)
+	}
+	if _, ok := method.Extensions["name"]; ok {
+		t.Error("Extensions holds the name field")
+	}
+}
+
+func TestExtensionsMarshalSortedAfterFields(t *testing.T) {
+	url := ExternalDocumentationObjectUrl("https://example.com")
+	docs := ExternalDocumentationObject{Url: &url, Extensions: map[string]json.RawMessage{
+		"x-b":         json.RawMessage(`2`),
+		"x-a":         json.RawMessage(`1`),
+		"description": json.RawMessage(`"not an extension"`),
+	}}
+	out, err := json.Marshal(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"url":"https://example.com","x-a":1,"x-b":2}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestOrRefDiscriminatesOnRef(t *testing.T) {
+	var params MethodObjectParams
+	in := `[{"name": "id", "schema": true}, {"$ref": "#/components/contentDescriptors/id"}]`
+	roundTrip(t, in, &params)
+	if params[0].IsRef() || *params[0].Value().Name != "id" {
+		t.Errorf("params[0] = %+v, want a content descriptor", params[0])
+	}
+	if !params[1].IsRef() || params[1].Value() != nil || *params[1].Ref().Ref != "#/components/contentDescriptors/id" {
+		t.Errorf("params[1] = %+v, want a reference", params[1])
+	}
+}
+
+func TestOrRefRejectsRefSiblings(t *testing.T) {
+	var method MethodOrReference
+	in := `{"$ref": "#/components/methods/get_pet", "name": "get_pet"}`
+	if err := json.Unmarshal([]byte(in), &method); err == nil {
+		t.Errorf("got %+v, want an ambiguity error", method)
+	}
+}
+
+func TestOrRefRejectsUnknownObjects(t *testing.T) {
+	for _, in := range []string{`{}`, `{"x-note": "no known field"}`, `{"nmae": "get_pet"}`} {
+		var method MethodOrReference
+		if err := json.Unmarshal([]byte(in), &method); err == nil {
+			t.Errorf("%s: got %+v, want an error", in, method)
+		}
+	}
+	var doc OpenrpcDocument
+	in := `{"openrpc": "1.4.0", "info": {"title": "t", "version": "1"}, "methods": [{}]}`
+	if err := json.Unmarshal([]byte(in), &doc); err == nil {
+		t.Error("a document with an empty method decoded")
+	}
+}
+
+func TestOrRefRejectsNonObjects(t *testing.T) {
+	for _, in := range []string{`"#/components/tags/pets"`, `[]`, `null`, `{"$ref": 7}`} {
+		var tag TagOrReference
+		if err := json.Unmarshal([]byte(in), &tag); err == nil {
+			t.Errorf("%s: got %+v, want an error", in, tag)
+		}
+	}
+}
+
+// TestUnionGolden decodes the schemas in testdata/unions, one per anyOf keyword, and
+// checks both that the expected variant was chosen and that encoding them again
+// reproduces the file.
+func TestUnionGolden(t *testing.T) {
+	checks := map[string]func(*JSONSchemaObject) bool{
+		"items.json": func(s *JSONSchemaObject) bool {
+			list, tuple := (*s.Properties)["list"].JSONSchemaObject.Items, (*s.Properties)["tuple"].JSONSchemaObject.Items
+			return list.JSONSchema != nil && list.SchemaArray == nil &&
+				tuple.SchemaArray != nil && tuple.JSONSchema == nil && len(*tuple.SchemaArray) == 2
+		},
+		"type.json": func(s *JSONSchemaObject) bool {
+			single, nullable := (*s.Properties)["single"].JSONSchemaObject.Type, (*s.Properties)["nullable"].JSONSchemaObject.Type
+			return single.SimpleTypes != nil && single.ArrayOfSimpleTypes == nil && *single.SimpleTypes == SimpleTypesEnum6 &&
+				nullable.ArrayOfSimpleTypes != nil && nullable.SimpleTypes == nil && (*nullable.ArrayOfSimpleTypes)[1] == SimpleTypesEnum3
+		},
+		"dependencies.json": func(s *JSONSchemaObject) bool {
+			billing, card := (*s.Dependencies)["billing"], (*s.Dependencies)["card"]
+			return billing.JSONSchema != nil && billing.StringArray == nil &&
+				card.StringArray != nil && card.JSONSchema == nil && (*card.StringArray)[0] == "billing"
+		},
+	}
+	for name, check := range checks {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join("testdata", "unions", name)
+			golden, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var s JSONSchemaObject
+			if err := json.Unmarshal(golden, &s); err != nil {
+				t.Fatal(err)
+			}
+			if !check(&s) {
+				t.Errorf("wrong variants decoded: %+v", s)
+			}
+			out, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, '\n')
+			if *update {
+				if err := os.WriteFile(path, out, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !bytes.Equal(out, golden) {
+				t.Errorf("encoding differs from %s:\n%s", path, out)
+			}
+		})
+	}
+}
+
+func TestUnionMarshalUnset(t *testing.T) {
+	for _, v := range []interface{}{Items{}, Type{}, DependenciesSet{}} {
+		if out, err := json.Marshal(v); err == nil {
+			t.Errorf("%T: got %s, want an error", v, out)
+		}
+	}
+}
+
+func TestOrRefConstructors(t *testing.T) {
+	name := TagObjectName("pets")
+	value := NewValue(TagObject{Name: &name})
+	if value.IsRef() || value.Ref() != nil || *value.Value().Name != "pets" {
+		t.Errorf("NewValue = %+v", value)
+	}
+	ref := NewRef[TagObject]("#/components/tags/pets")
+	if !ref.IsRef() || ref.Value() != nil || *ref.Ref().Ref != "#/components/tags/pets" {
+		t.Errorf("NewRef = %+v", ref)
+	}
+	out, err := json.Marshal(MethodObjectTags{value, ref})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"name":"pets"},{"$ref":"#/components/tags/pets"}]`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+	if out, err := json.Marshal(OrRef[TagObject]{}); err == nil {
+		t.Errorf("zero OrRef: got %s, want an error", out)
+	}
+}
+
+func TestOrRefOfOtherTypes(t *testing.T) {
+	var o OrRef[map[string]int]
+	if err := json.Unmarshal([]byte(`{}`), &o); err != nil || o.IsRef() || *o.Value() == nil {
+		t.Errorf("{}: got %+v, %v, want an empty map", o, err)
+	}
+	if err := json.Unmarshal([]byte(`{"$ref": "#/x", "a": 1}`), &o); err == nil {
+		t.Errorf("$ref with siblings: got %+v, want an error", o)
+	}
+}
+
+func TestIntegersAcceptIntegralNumbers(t *testing.T) {
+	var s JSONSchemaObject
+	if err := json.Unmarshal([]byte(`{"maxLength": 2.0, "minItems": 1e1, "minLength": 3}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if *s.MaxLength != 2 || *s.MinItems != 10 || *s.MinLength != 3 {
+		t.Errorf("maxLength, minItems, minLength = %d, %d, %d", *s.MaxLength, *s.MinItems, *s.MinLength)
+	}
+	var e ErrorObject
+	if err := json.Unmarshal([]byte(`{"code": -32000.0, "message": "m"}`), &e); err != nil || *e.Code != -32000 {
+		t.Errorf("code = %v, %v", e.Code, err)
+	}
+	for _, in := range []string{`{"maxLength": 2.5}`, `{"maxLength": "2"}`, `{"maxLength": 1e30}`} {
+		var s JSONSchemaObject
+		if err := json.Unmarshal([]byte(in), &s); err == nil {
+			t.Errorf("%s: got %d, want an error", in, *s.MaxLength)
+		}
+	}
+}
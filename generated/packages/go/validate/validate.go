@@ -0,0 +1,152 @@
+// Package validate checks OpenRPC documents against the meta-schema embedded in the
+// package of their version, as RawOpenrpcDocument.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zcstarr/spec-types/generated/packages/go/jsonschema"
+	"github.com/zcstarr/spec-types/generated/packages/go/source"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_3"
+	"github.com/zcstarr/spec-types/generated/packages/go/v1_4"
+)
+
+// Violation is a place where a document does not conform to its meta-schema.
+type Violation struct {
+	// Pointer is the JSON pointer of the offending value within the document.
+	Pointer string
+	// Keyword is the meta-schema keyword the value fails, such as "required".
+	Keyword string
+	Message string
+	// Position locates the value in the document's text, or its closest ancestor
+	// for values that are missing.
+	Position source.Position
+}
+
+func (v Violation) Error() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", v.Position, pointer, v.Message, v.Keyword)
+}
+
+var metaSchemas = map[string]*metaSchema{
+	"v1_3": {raw: v1_3.RawOpenrpcDocument},
+	"v1_4": {raw: v1_4.RawOpenrpcDocument},
+}
+
+// metaSchema compiles a meta-schema on first use.
+type metaSchema struct {
+	raw       string
+	once      sync.Once
+	validator *jsonschema.Validator
+	err       error
+}
+
+func (m *metaSchema) get() (*jsonschema.Validator, error) {
+	m.once.Do(func() {
+		var schema v1_4.JSONSchema
+		if m.err = json.Unmarshal([]byte(m.raw), &schema); m.err == nil {
+			m.validator, m.err = jsonschema.New(&schema)
+		}
+	})
+	return m.validator, m.err
+}
+
+// Document validates data against the meta-schema of the version its "openrpc" field
+// names, returning every violation along with its position in data. Documents
+// without a version any package supports are validated against the latest
+// meta-schema. The error is only set when data is not JSON.
+func Document(data []byte) ([]Violation, error) {
+	value, err := jsonschema.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding OpenRPC document: %w", err)
+	}
+	pkg := "v1_4"
+	if object, ok := value.(map[string]interface{}); ok {
+		if s, ok := object["openrpc"].(string); ok {
+			if version, err := v1_4.ParseOpenrpcVersion(s); err == nil && version.Package() == "v1_3" {
+				pkg = "v1_3"
+			}
+		}
+	}
+	validator, err := metaSchemas[pkg].get()
+	if err != nil {
+		return nil, fmt.Errorf("compiling the %s meta-schema: %w", pkg, err)
+	}
+	m, err := source.Index(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding OpenRPC document: %w", err)
+	}
+	var violations []Violation
+	for _, e := range flatten(validator.Validate(value)) {
+		position, _ := m.Nearest(e.InstancePath)
+		violations = append(violations, Violation{Pointer: e.InstancePath, Keyword: e.Keyword, Message: e.Message, Position: position})
+	}
+	return violations, nil
+}
+
+// shapeKeywords are the keywords that tell what kind of value a schema describes.
+var shapeKeywords = map[string]bool{
+	"type":                 true,
+	"required":             true,
+	"additionalProperties": true,
+	"enum":                 true,
+	"const":                true,
+	"false":                true,
+}
+
+// flatten replaces each failed anyOf and oneOf with the errors of its closest
+// alternative, as a document usually means to be a value of that kind. The closest
+// alternative is the one whose shape the value misses the least, judged by the
+// errors on the value itself rather than on values nested in it, then the one with
+// the fewest errors. Ties keep the combinator error itself.
+func flatten(errs []jsonschema.Error) []jsonschema.Error {
+	var flat []jsonschema.Error
+	for _, e := range errs {
+		if (e.Keyword != "anyOf" && e.Keyword != "oneOf") || len(e.Causes) == 0 {
+			flat = append(flat, e)
+			continue
+		}
+		branches := map[int][]jsonschema.Error{}
+		for _, cause := range e.Causes {
+			rest := strings.TrimPrefix(cause.SchemaPath, e.SchemaPath+"/")
+			i, err := strconv.Atoi(strings.SplitN(rest, "/", 2)[0])
+			if err != nil {
+				i = -1
+			}
+			branches[i] = append(branches[i], cause)
+		}
+		score := func(branch []jsonschema.Error) [2]int {
+			misses := 0
+			for _, cause := range branch {
+				if cause.InstancePath == e.InstancePath && shapeKeywords[cause.Keyword] {
+					misses++
+				}
+			}
+			return [2]int{misses, len(branch)}
+		}
+		best, found, tie := 0, false, false
+		for i, branch := range branches {
+			s, b := score(branch), score(branches[best])
+			switch {
+			case !found || s[0] < b[0] || s[0] == b[0] && s[1] < b[1]:
+				best, found, tie = i, true, false
+			case s == b:
+				tie = true
+			}
+		}
+		if tie {
+			e.Causes = nil
+			flat = append(flat, e)
+			continue
+		}
+		flat = append(flat, flatten(branches[best])...)
+	}
+	return flat
+}
@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			"valid",
+			`{"openrpc":"1.4.0","info":{"title":"t","version":"1"},"methods":[]}`,
+			nil,
+		},
+		{
+			"1.3 meta-schema",
+			`{"openrpc":"1.3.2","info":{"title":"t","version":"1"},"methods":[{"name":"a","params":[],"result":{"name":"r","schema":{"type":"strin"}}}]}`,
+			[]string{"/methods/0/result/schema/type anyOf"},
+		},
+		{
+			"closest alternatives",
+			`{"openrpc":"1.4.0","info":{"title":"t"},"methods":[{"params":[{"name":"p"}],"errors":[{"code":"x","message":"m"}]},{"$ref":"#/x","y":1}],"x-foo":1,"bogus":2}`,
+			[]string{
+				" additionalProperties",
+				"/info required",
+				"/methods/0 required",
+				"/methods/0/errors/0/code type",
+				"/methods/0/params/0 required",
+				"/methods/1 additionalProperties",
+			},
+		},
+		{
+			"unknown version",
+			`{"openrpc":"2.0.0","info":{"title":"t","version":"1"},"methods":[],"components":{"schemas":{"A":{"type":"object","properties":{"a":{"minLength":-1}}}}}}`,
+			[]string{"/components/schemas/A/properties/a/minLength minimum"},
+		},
+	} {
+		violations, err := Document([]byte(test.doc))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		var got []string
+		for _, v := range violations {
+			got = append(got, v.Pointer+" "+v.Keyword)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDocumentPositions(t *testing.T) {
+	doc := "{\n  \"openrpc\": \"1.4.0\",\n  \"info\": {\"title\": \"t\"},\n  \"methods\": []\n}"
+	violations, err := Document([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("got %v, want one violation", violations)
+	}
+	v := violations[0]
+	if v.Position.Line != 3 || v.Position.Column != 3 {
+		t.Errorf("position = %v, want 3:3, where the info member starts", v.Position)
+	}
+	if want := `3:3: /info: missing required property "version" (required)`; v.Error() != want {
+		t.Errorf("message = %q, want %q", v.Error(), want)
+	}
+}
+
+func TestDocumentNotJSON(t *testing.T) {
+	if _, err := Document([]byte(`{"openrpc":`)); err == nil {
+		t.Error("got no error for truncated JSON")
+	}
+}
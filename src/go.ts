@@ -0,0 +1,484 @@
+import { StringUtils } from "./util.ts";
+
+// Post-processing for the transpiler's Go output.
+// The transpiler falls back to `interface{}` for schemas it cannot type, so the
+// patches below swap those declarations for real Go types while leaving the rest
+// of the generated code untouched.
+
+type GoPatch = (code: string, schema: any) => string;
+
+interface GoField {
+  name: string;
+  type: string;
+  tag: string;
+}
+
+const goTypeName = (title: string): string => StringUtils.upperFirst(title);
+
+const goFieldName = (key: string): string => StringUtils.upperFirst(key.replace(/[^a-zA-Z0-9]/g, ""));
+
+const resolveSchemaRef = (schema: any, ref: string): any => {
+  const name = ref.replace(/^#\/definitions\//, "");
+  const target = schema.definitions?.[name];
+  if (!target) throw new Error(`Unable to resolve ${ref}`);
+  return target;
+};
+
+// Renders a struct the way gofmt aligns it, matching the transpiler's own structs
+const goStruct = (name: string, fields: GoField[]): string => {
+  const nameWidth = Math.max(...fields.map((f) => f.name.length));
+  const typeWidth = Math.max(...fields.map((f) => f.type.length));
+  const body = fields.map((f) => `\t${f.name.padEnd(nameWidth)} ${f.type.padEnd(typeWidth)} ${f.tag}`);
+  return [`type ${name} struct {`, ...body, "}"].join("\n");
+};
+
+// Finds the line range of a top-level type declaration, including a trailing const block
+const findGoDecl = (lines: string[], name: string): [number, number] | undefined => {
+  const start = lines.findIndex((line) => line.startsWith(`type ${name} `));
+  if (start === -1) return undefined;
+  let end = start;
+  if (lines[start]!.endsWith("{")) {
+    while (lines[end] !== "}") end++;
+  }
+  if (lines[end + 1] === "const (") {
+    end++;
+    while (lines[end] !== ")") end++;
+  }
+  return [start, end];
+};
+
+// Drops the methods declared on a type along with the comment lines directly above them
+const removeGoMethods = (lines: string[], name: string): string[] => {
+  const receiver = new RegExp(`^func \\(\\w+ \\*?${name}\\) `);
+  const out: string[] = [];
+  for (let i = 0; i < lines.length; i++) {
+    if (!receiver.test(lines[i]!)) {
+      out.push(lines[i]!);
+      continue;
+    }
+    while (out.length && out[out.length - 1]!.startsWith("//")) out.pop();
+    while (lines[i] !== "}") i++;
+  }
+  return out;
+};
+
+// Replaces a type declaration (and its methods) with the given Go source, keeping its doc comment
+export const replaceGoDecl = (code: string, name: string, decl: string): string => {
+  const lines = removeGoMethods(code.split("\n"), name);
+  const range = findGoDecl(lines, name);
+  if (!range) throw new Error(`Go declaration ${name} not found`);
+  const [start, end] = range;
+  return [...lines.slice(0, start), decl, ...lines.slice(end + 1)].join("\n");
+};
+
+// Builds a struct from an object definition that the transpiler emitted as interface{}
+// because it only describes its shape through properties and the ^x- extension pattern.
+const typeObjectDefinition = (definition: string): GoPatch => (code, schema) => {
+  const def = schema.definitions?.[definition];
+  const name = goTypeName(def?.title ?? "");
+  if (!def || !code.includes(`\ntype ${name} interface{}\n`)) return code;
+  const required: string[] = def.required ?? [];
+  const fields = Object.entries<any>(def.properties).map(([key, prop]) => {
+    const title = prop.title ?? resolveSchemaRef(schema, prop.$ref).title;
+    const omit = required.includes(key) ? "" : ",omitempty";
+    return { name: goFieldName(key), type: `*${goTypeName(title)}`, tag: `\`json:"${key}${omit}"\`` };
+  });
+  return replaceGoDecl(code, name, goStruct(name, fields));
+};
+
+// Replaces a declaration the transpiler emitted as interface{} with the given Go source
+const typeUntyped = (name: string, decl: string): GoPatch => (code) => {
+  if (!code.includes(`\ntype ${name} interface{}\n`)) return code;
+  return replaceGoDecl(code, name, decl);
+};
+
+// Narrows a map the transpiler left as map[string]interface{} to the given value type
+const typeMap = (name: string, value: string): GoPatch => (code) => {
+  const untyped = `\ntype ${name} map[string]interface{}\n`;
+  if (!code.includes(untyped)) return code;
+  return code.replace(untyped, `\ntype ${name} map[string]${value}\n`);
+};
+
+// Lists the values of a generated string enum in a Known<name> slice, declared after its consts
+const listEnum = (name: string): GoPatch => (code) => {
+  const lines = code.split("\n");
+  const decl = findGoDecl(lines, name);
+  if (!decl) return code;
+  const [start, end] = decl;
+  const values = lines
+    .slice(start + 1, end)
+    .map((line) => line.match(/^\t(\w+) /)?.[1])
+    .filter((value): value is string => value !== undefined);
+  if (!values.length) return code;
+  const known = [
+    `// Known${name} lists the values of ${name} enumerated by the meta-schema, in its order.`,
+    `var Known${name} = []${name}{`,
+    ...values.map((value) => `\t${value},`),
+    "}",
+  ];
+  return [...lines.slice(0, end + 1), ...known, ...lines.slice(end + 1)].join("\n");
+};
+
+const linkObjectParams = [
+  "type LinkObjectParams map[string]RuntimeExpression",
+  "// A constant or a [runtime expression](#runtime-expression) evaluated and passed to the linked method.",
+  "type RuntimeExpression interface{}",
+].join("\n");
+
+// Adds import lines next to the transpiler's own, keeping them sorted
+const addGoImports = (code: string, ...imports: string[]): string => {
+  const lines = code.split("\n");
+  const first = lines.findIndex((line) => line.startsWith("import "));
+  const last = lines.findLastIndex((line) => line.startsWith("import "));
+  const existing = lines.slice(first, last + 1);
+  const added = imports.map((i) => `import "${i}"`);
+  const sorted = [...new Set([...existing, ...added])].sort();
+  return [...lines.slice(0, first), ...sorted, ...lines.slice(last + 1)].join("\n");
+};
+
+// Titles of every schema that allows ^x- specification extensions, either directly or through its $ref
+const extensibleTitles = (schema: any): string[] => {
+  const allowsExtensions = (node: any): boolean => {
+    if (node?.patternProperties?.["^x-"]) return true;
+    const ref = node?.$ref;
+    return typeof ref === "string" && ref.startsWith("#/") && allowsExtensions(resolveSchemaRef(schema, ref));
+  };
+  const titles = new Set<string>();
+  const walk = (node: any): void => {
+    if (Array.isArray(node)) return node.forEach(walk);
+    if (!node || typeof node !== "object") return;
+    if (typeof node.title === "string" && allowsExtensions(node)) titles.add(node.title);
+    Object.values(node).forEach(walk);
+  };
+  walk(schema);
+  return [...titles];
+};
+
+const goExtensionMethods = (name: string): string => `// UnmarshalJSON implements the json Unmarshaler interface.
+// Specification extensions (^x- fields) are collected into Extensions.
+func (o *${name}) UnmarshalJSON(bytes []byte) error {
+	type plain ${name}
+	var p plain
+	if err := json.Unmarshal(bytes, &p); err != nil {
+		return err
+	}
+	extensions, err := unmarshalExtensions(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ${name}(p)
+	o.Extensions = extensions
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting Extensions after the object's fields.
+func (o ${name}) MarshalJSON() ([]byte, error) {
+	type plain ${name}
+	return marshalExtensions(plain(o), o.Extensions)
+}`;
+
+const goExtensionHelpers = `// unmarshalExtensions collects the ^x- specification extension fields of a JSON object.
+func unmarshalExtensions(bytes []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	var extensions map[string]json.RawMessage
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if extensions == nil {
+			extensions = map[string]json.RawMessage{}
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+// marshalExtensions marshals v and appends the ^x- specification extension fields, sorted by key.
+func marshalExtensions(v interface{}, extensions map[string]json.RawMessage) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return bytes, err
+	}
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		if strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	out := append([]byte{}, bytes[:len(bytes)-1]...)
+	for _, key := range keys {
+		if len(out) > 1 {
+			out = append(out, ',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, name...)
+		out = append(out, ':')
+		out = append(out, extensions[key]...)
+	}
+	return append(out, '}'), nil
+}`;
+
+// Gives every extensible object an Extensions field that round-trips its ^x- fields
+const withExtensions: GoPatch = (code, schema) => {
+  const names = extensibleTitles(schema)
+    .map(goTypeName)
+    .filter((name) => code.includes(`\ntype ${name} struct {\n`));
+  if (!names.length) return code;
+  const patched = names.reduce((acc, name) => {
+    const lines = acc.split("\n");
+    const [start, end] = findGoDecl(lines, name)!;
+    const fields = lines.slice(start + 1, end).map((line) => {
+      const [, fieldName, type, tag] = line.match(/^\t(\S+)\s+(\S+)\s+(`.*`)$/)!;
+      return { name: fieldName!, type: type!, tag: tag! };
+    });
+    fields.push({ name: "Extensions", type: "map[string]json.RawMessage", tag: '`json:"-"`' });
+    const decl = [goStruct(name, fields), goExtensionMethods(name)].join("\n");
+    return [...lines.slice(0, start), decl, ...lines.slice(end + 1)].join("\n");
+  }, code);
+  return `${addGoImports(patched, "sort", "strings")}\n${goExtensionHelpers}`;
+};
+
+const goOrRef = (members: Map<string, string[]>): string => `// OrRef holds either a T or a Reference Object pointing at one.
+// When unmarshalling, the variant is chosen by the presence of "$ref": a Reference Object
+// carries it and nothing else, while a T never does and has at least one of its fields.
+// Any other input is rejected.
+type OrRef[T any] struct {
+	value     *T
+	reference *ReferenceObject
+}
+// NewValue returns an OrRef holding v.
+func NewValue[T any](v T) OrRef[T] {
+	return OrRef[T]{value: &v}
+}
+// NewRef returns an OrRef holding a Reference Object to ref.
+func NewRef[T any](ref string) OrRef[T] {
+	r := Ref(ref)
+	return OrRef[T]{reference: &ReferenceObject{Ref: &r}}
+}
+// IsRef reports whether o holds a Reference Object.
+func (o OrRef[T]) IsRef() bool {
+	return o.reference != nil
+}
+// Value returns the T held by o, or nil when o holds a Reference Object.
+func (o OrRef[T]) Value() *T {
+	return o.value
+}
+// Ref returns the Reference Object held by o, or nil when o holds a T.
+func (o OrRef[T]) Ref() *ReferenceObject {
+	return o.reference
+}
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (o *OrRef[T]) UnmarshalJSON(bytes []byte) error {
+	isReference, err := isReferenceObject(bytes, jsonFields(new(T)))
+	if err != nil {
+		return err
+	}
+	if isReference {
+		var myReferenceObject ReferenceObject
+		if err := json.Unmarshal(bytes, &myReferenceObject); err != nil {
+			return err
+		}
+		*o = OrRef[T]{reference: &myReferenceObject}
+		return nil
+	}
+	var myValue T
+	if err := json.Unmarshal(bytes, &myValue); err != nil {
+		return err
+	}
+	*o = OrRef[T]{value: &myValue}
+	return nil
+}
+func (o OrRef[T]) MarshalJSON() ([]byte, error) {
+	if o.value != nil {
+		return json.Marshal(o.value)
+	}
+	if o.reference != nil {
+		return json.Marshal(o.reference)
+	}
+	return nil, errors.New("failed to marshal any one of the object properties")
+}
+// jsonFields returns the JSON names of the fields of the T an OrRef holds, or nil when T is
+// not one of the generated objects.
+func jsonFields(v any) []string {
+	switch v.(type) {
+${[...members].map(([member, fields]) => `\tcase *${member}:\n\t\treturn []string{${fields.map((field) => JSON.stringify(field)).join(", ")}}`).join("\n")}
+	}
+	return nil
+}
+// isReferenceObject reports whether a JSON object is a Reference Object, i.e. has a "$ref" field.
+// Objects mixing "$ref" with other fields are ambiguous and rejected, as are objects without any
+// of the given field names of the other variant and anything but an object. Nil names accept
+// any object without "$ref".
+func isReferenceObject(bytes []byte, names []string) (bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &fields); err != nil || fields == nil {
+		return false, errors.New("expected a JSON object or a Reference Object")
+	}
+	if _, ok := fields["$ref"]; !ok {
+		if names == nil {
+			return false, nil
+		}
+		for _, name := range names {
+			if _, ok := fields[name]; ok {
+				return false, nil
+			}
+		}
+		return false, errors.New("object matches neither variant: no known field and no $ref")
+	}
+	if len(fields) > 1 {
+		return false, errors.New("ambiguous object: $ref cannot be combined with other fields")
+	}
+	return true, nil
+}`;
+
+const goArrayUnion = (name: string, member: string, array: string): string => `// UnmarshalJSON implements the json Unmarshaler interface.
+// JSON arrays decode into ${array} and any other value into ${member}, so exactly one variant is set.
+func (o *${name}) UnmarshalJSON(bytes []byte) error {
+	if isJSONArray(bytes) {
+		var my${array} ${array}
+		if err := json.Unmarshal(bytes, &my${array}); err != nil {
+			return err
+		}
+		*o = ${name}{${array}: &my${array}}
+		return nil
+	}
+	var my${member} ${member}
+	if err := json.Unmarshal(bytes, &my${member}); err != nil {
+		return err
+	}
+	*o = ${name}{${member}: &my${member}}
+	return nil
+}
+// MarshalJSON implements the json Marshaler interface, emitting the variant that is set unwrapped.
+func (o ${name}) MarshalJSON() ([]byte, error) {
+	if o.${member} != nil {
+		return json.Marshal(o.${member})
+	}
+	if o.${array} != nil {
+		return json.Marshal(o.${array})
+	}
+	return nil, errors.New("failed to marshal any one of the object properties")
+}`;
+
+const goArrayHelpers = `// isJSONArray reports whether a JSON value is an array.
+func isJSONArray(bytes []byte) bool {
+	return strings.HasPrefix(strings.TrimLeft(string(bytes), " \\t\\r\\n"), "[")
+}`;
+
+// The transpiler's anyOf unions fill every variant that unmarshals and always marshal to an
+// array, so "type": "string" came back as ["string"]. Every such union pairs a single value
+// with an array of them, which is enough to pick the variant from the JSON alone.
+const discriminateArrays: GoPatch = (code) => {
+  const unions = [
+    ...code.matchAll(/^type (\w+) struct \{\n\t(\w+) +\*(\w+)\n\t(\w+) +\*(\w+)\n\}\nfunc \(a \*\1\) UnmarshalJSON/gm),
+  ];
+  if (!unions.length) return code;
+  const isArray = (type: string) => code.includes(`\ntype ${type} []`);
+  const patched = unions.reduce((acc, [match, name, first, , second]) => {
+    const struct = match.slice(0, match.indexOf("\n}") + 2);
+    const [member, array] = isArray(second!) ? [first!, second!] : [second!, first!];
+    if (!isArray(array)) throw new Error(`Go union ${name} has no array variant`);
+    return replaceGoDecl(acc, name!, [struct, goArrayUnion(name!, member, array)].join("\n"));
+  }, code);
+  return `${addGoImports(patched, "strings")}\n${goArrayHelpers}`;
+};
+
+// Lists the JSON names of the fields of a generated struct, in declaration order
+const goJSONFields = (code: string, name: string): string[] => {
+  const lines = code.split("\n");
+  const [start, end] = findGoDecl(lines, name)!;
+  return lines
+    .slice(start + 1, end)
+    .map((line) => line.match(/`json:"([^",]+)/)?.[1])
+    .filter((field): field is string => field !== undefined && field !== "-");
+};
+
+// Collapses the transpiler's Object/Reference unions, one struct and pair of methods per
+// wrapper, into aliases of a single generic OrRef that discriminates on "$ref".
+const genericReferences: GoPatch = (code) => {
+  const unions = [...code.matchAll(/^type (\w+) struct \{\n\t\w+ +\*(\w+)\n\tReferenceObject +\*ReferenceObject\n\}$/gm)];
+  if (!unions.length) return code;
+  const members = new Map(unions.map(([, , member]) => [member!, goJSONFields(code, member!)]));
+  const patched = unions.reduce((acc, [, name, member]) => {
+    return replaceGoDecl(acc, name!, `type ${name} = OrRef[${member}]`);
+  }, code);
+  return `${patched}\n${goOrRef(members)}`;
+};
+
+// A const of null decodes to a nil *AlwaysTrue, the same as no const at all, so the raw
+// JSON is kept instead: an explicit null stays "null" and a missing const is empty.
+const rawConst: GoPatch = (code) =>
+  code.replace(/^(\tConst +)\*AlwaysTrue( +)(`json:"const,omitempty"`)$/m, (_, field, pad, tag) => {
+    return `${field}${"json.RawMessage".padEnd("*AlwaysTrue".length + pad.length)}${tag}`;
+  });
+
+const goIntegerMethods = (name: string): string => `// UnmarshalJSON implements the json Unmarshaler interface.
+// Numbers with a zero fractional part, such as 2.0, are integers to JSON Schema and decode too.
+func (o *${name}) UnmarshalJSON(bytes []byte) error {
+	i, err := decodeInteger(bytes)
+	if err != nil {
+		return err
+	}
+	*o = ${name}(i)
+	return nil
+}`;
+
+const goIntegerHelpers = `// decodeInteger decodes a JSON number that has no fractional part, however it is written.
+func decodeInteger(bytes []byte) (int64, error) {
+	var n json.Number
+	if strings.HasPrefix(string(bytes), \`"\`) || json.Unmarshal(bytes, &n) != nil {
+		return 0, errors.New("json: cannot unmarshal " + string(bytes) + " into an integer")
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errors.New("json: cannot unmarshal " + string(n) + " into an integer")
+	}
+	return int64(f), nil
+}`;
+
+// encoding/json refuses to decode 2.0 into the transpiler's int64 types, although JSON
+// Schema counts it as an integer, so every such type decodes integral numbers itself.
+const integralIntegers: GoPatch = (code) => {
+  const names = [...code.matchAll(/^type (\w+) int64$/gm)].map(([, name]) => name!);
+  if (!names.length) return code;
+  const patched = names.reduce((acc, name) => {
+    return replaceGoDecl(acc, name, [`type ${name} int64`, goIntegerMethods(name)].join("\n"));
+  }, code);
+  return `${addGoImports(patched, "math", "strings")}\n${goIntegerHelpers}`;
+};
+
+const goPatches: GoPatch[] = [
+  listEnum("Openrpc"),
+  typeObjectDefinition("infoObject"),
+  typeUntyped("LinkObjectName", "type LinkObjectName string"),
+  typeUntyped("LinkObjectParams", linkObjectParams),
+  typeObjectDefinition("linkObject"),
+  typeMap("ServerObjectVariables", "ServerObjectVariable"),
+  typeMap("Definitions", "JSONSchema"),
+  typeMap("Properties", "JSONSchema"),
+  typeMap("PatternProperties", "JSONSchema"),
+  typeMap("Dependencies", "DependenciesSet"),
+  typeMap("SchemaComponents", "JSONSchema"),
+  typeMap("LinkComponents", "LinkObject"),
+  typeMap("ErrorComponents", "ErrorObject"),
+  typeMap("ExampleComponents", "ExampleObject"),
+  typeMap("ExamplePairingComponents", "ExamplePairingObject"),
+  typeMap("ContentDescriptorComponents", "ContentDescriptorObject"),
+  typeMap("TagComponents", "TagObject"),
+  genericReferences,
+  discriminateArrays,
+  withExtensions,
+  rawConst,
+  integralIntegers,
+];
+
+export const patchGo = (code: string, schema: any): string =>
+  goPatches.reduce((acc, patch) => patch(acc, schema), code);
@@ -1,5 +1,6 @@
 import Transpiler from "@json-schema-tools/transpiler";
 import { compileTypescript } from "./util";
+import { patchGo } from "./go.ts";
 import { buildPackageJson, buildTsConfig, buildCargoToml, buildGoMod, buildPyProjectToml } from "./assets.ts";
 import {readFile, writeFile, mkdir, rm} from "fs/promises";
 import Dereferencer from "@json-schema-tools/dereferencer";
@@ -123,7 +124,8 @@ const rsLibFile = (schemaNames: string[]): string => {
 
 const goPackageFile = (name: string, goCode: string, rawSchema: string): string => {
   const escaped = JSON.stringify(rawSchema);
-  return `package v${name}\n\n${goCode}\n\nconst RawOpenrpcDocument = ${escaped}\n`;
+  const patched = patchGo(goCode, JSON.parse(rawSchema));
+  return `package v${name}\n\n${patched}\n\nconst RawOpenrpcDocument = ${escaped}\n`;
 }
 
 const pyInitFile = (schemaNames: string[]): string => {
@@ -205,14 +207,15 @@ const generateRsOp = (getTranspiler: GetTranspiler, schemasNames: string[], outp
 
 const generateGoOp = (getTranspiler: GetTranspiler, schemasNames: string[], outpath: string, assets: PackageAssets): Op[] => {
   const schemas: Record<string, any> = getAllSchemas();
+  // Only the generated files are replaced, hand-written Go sources next to them are kept
   const ops: Op[] = [
-    { type: "rm", path: outpath },
     { type: "mkdir", path: outpath },
   ];
 
   return ops
     .concat(
       schemasNames.flatMap((name) => [
+        { type: "rm" as const, path: `${outpath}/v${name}/v${name}.go` },
         { type: "mkdir" as const, path: `${outpath}/v${name}` },
         {
           type: "write" as const,